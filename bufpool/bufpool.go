@@ -0,0 +1,188 @@
+// Package bufpool provides a byte buffer pool built on top of pool.Pool, keeping one pool per
+// power-of-two size class so buffers are handed out according to the requested size.
+package bufpool
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+)
+
+var (
+	// ErrInvalidSize is returned when the size classes can't be built from the given sizes.
+	ErrInvalidSize = errors.New("invalid buffer size")
+
+	errNilBuffer = errors.New("buffer is nil")
+)
+
+// BufPool is a byte buffer pool with one pool.Pool per size class.
+// Size classes are the powers of two between the minimum and maximum buffer sizes.
+type BufPool struct {
+	// classes holds one pool per size class, ordered from the smallest to the largest size.
+	classes []*pool.Pool[*[]byte]
+
+	// sizes holds the buffer capacity of each size class.
+	sizes []int
+
+	// minShift is log2 of the smallest size class, used to map sizes to class indexes.
+	minShift int
+}
+
+// ClassStats holds the statistics of a single size class.
+type ClassStats struct {
+	// Size is the capacity of the buffers in this class.
+	Size int
+
+	*pool.PoolStatsSnapshot
+}
+
+// New creates a buffer pool with size classes for every power of two between minSize and
+// maxSize, both rounded up to a power of two. Each class is a pool.Pool created with config,
+// so limits such as the hard limit apply per class. A nil config uses the pool defaults.
+func New(minSize, maxSize int, config *pool.PoolConfig[*[]byte]) (*BufPool, error) {
+	if minSize <= 0 {
+		return nil, fmt.Errorf("%w: minSize must be greater than 0, got %d", ErrInvalidSize, minSize)
+	}
+
+	if maxSize < minSize {
+		return nil, fmt.Errorf("%w: maxSize (%d) must be >= minSize (%d)", ErrInvalidSize, maxSize, minSize)
+	}
+
+	minShift := ceilLog2(minSize)
+	maxShift := ceilLog2(maxSize)
+
+	bp := &BufPool{
+		minShift: minShift,
+	}
+	for shift := minShift; shift <= maxShift; shift++ {
+		size := 1 << shift
+
+		p, err := newClassPool(size, config)
+		if err != nil {
+			bp.Close()
+			return nil, fmt.Errorf("failed to create size class %d: %w", size, err)
+		}
+
+		bp.classes = append(bp.classes, p)
+		bp.sizes = append(bp.sizes, size)
+	}
+
+	return bp, nil
+}
+
+// newClassPool creates the pool backing the size class of the given size.
+func newClassPool(size int, config *pool.PoolConfig[*[]byte]) (*pool.Pool[*[]byte], error) {
+	allocator := func() *[]byte {
+		buf := make([]byte, 0, size)
+		return &buf
+	}
+
+	cleaner := func(buf *[]byte) {
+		*buf = (*buf)[:0]
+	}
+
+	p, err := pool.NewPool(config, allocator, cleaner, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.(*pool.Pool[*[]byte]), nil
+}
+
+// Get returns a buffer of length size from the smallest class that can hold it.
+// Sizes above the largest class are allocated directly and aren't pooled.
+func (bp *BufPool) Get(size int) (*[]byte, error) {
+	if size < 0 {
+		return nil, fmt.Errorf("%w: size must be >= 0, got %d", ErrInvalidSize, size)
+	}
+
+	idx := bp.classFor(size)
+	if idx >= len(bp.classes) {
+		buf := make([]byte, size)
+		return &buf, nil
+	}
+
+	buf, err := bp.classes[idx].Get()
+	if err != nil {
+		return nil, err
+	}
+
+	*buf = (*buf)[:size]
+	return buf, nil
+}
+
+// Put returns a buffer to the largest class whose size fits in its capacity, with its length
+// reset to 0. Buffers smaller than the smallest class or larger than the largest one are dropped.
+// A buffer that outgrew its class through append goes to a larger class than the one that
+// handed it out, which then counts it as in use, so Close waits for it as for any object
+// that isn't returned.
+func (bp *BufPool) Put(buf *[]byte) error {
+	if buf == nil {
+		return errNilBuffer
+	}
+
+	idx, ok := bp.classForCap(cap(*buf))
+	if !ok {
+		return nil
+	}
+
+	*buf = (*buf)[:0]
+	return bp.classes[idx].Put(buf)
+}
+
+// Stats returns the statistics of every size class, ordered from the smallest size.
+func (bp *BufPool) Stats() []ClassStats {
+	stats := make([]ClassStats, 0, len(bp.classes))
+	for i, p := range bp.classes {
+		stats = append(stats, ClassStats{
+			Size:              bp.sizes[i],
+			PoolStatsSnapshot: p.GetPoolStatsSnapshot(),
+		})
+	}
+	return stats
+}
+
+// Sizes returns the buffer capacity of every size class.
+func (bp *BufPool) Sizes() []int {
+	return append([]int(nil), bp.sizes...)
+}
+
+// Close closes the pools of every size class.
+func (bp *BufPool) Close() error {
+	var errs []error
+	for _, p := range bp.classes {
+		if err := p.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// classFor returns the index of the smallest class that can hold size bytes.
+// The result is len(bp.classes) when no class is big enough.
+func (bp *BufPool) classFor(size int) int {
+	shift := ceilLog2(size)
+	if shift < bp.minShift {
+		return 0
+	}
+	return shift - bp.minShift
+}
+
+// classForCap returns the index of the largest class whose size is at most capacity.
+// Returns false if capacity is below the smallest class or above the largest one.
+func (bp *BufPool) classForCap(capacity int) (int, bool) {
+	if capacity < bp.sizes[0] || capacity > bp.sizes[len(bp.sizes)-1] {
+		return 0, false
+	}
+	return bits.Len(uint(capacity)) - 1 - bp.minShift, true
+}
+
+// ceilLog2 returns the smallest n such that 1<<n >= size.
+func ceilLog2(size int) int {
+	if size <= 1 {
+		return 0
+	}
+	return bits.Len(uint(size - 1))
+}
@@ -0,0 +1,138 @@
+package bufpool_test
+
+import (
+	"testing"
+
+	"github.com/AlexsanderHamir/PoolX/v2/bufpool"
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createBufPool(t *testing.T, minSize, maxSize int) *bufpool.BufPool {
+	config, err := pool.NewPoolConfigBuilder[*[]byte]().
+		SetInitialCapacity(4).
+		SetHardLimit(16).
+		SetMinShrinkCapacity(4).
+		SetFastPathInitialSize(4).
+		Build()
+	require.NoError(t, err)
+
+	bp, err := bufpool.New(minSize, maxSize, config)
+	require.NoError(t, err)
+	return bp
+}
+
+func TestBufPoolSizeClasses(t *testing.T) {
+	bp := createBufPool(t, 50, 1000)
+	defer func() {
+		require.NoError(t, bp.Close())
+	}()
+
+	assert.Equal(t, []int{64, 128, 256, 512, 1024}, bp.Sizes())
+
+	tests := []struct {
+		size        int
+		expectedCap int
+	}{
+		{size: 0, expectedCap: 64},
+		{size: 10, expectedCap: 64},
+		{size: 64, expectedCap: 64},
+		{size: 65, expectedCap: 128},
+		{size: 1000, expectedCap: 1024},
+		{size: 1024, expectedCap: 1024},
+		{size: 5000, expectedCap: 5000},
+	}
+
+	for _, tt := range tests {
+		buf, err := bp.Get(tt.size)
+		require.NoError(t, err)
+		assert.Len(t, *buf, tt.size)
+		assert.Equal(t, tt.expectedCap, cap(*buf), "size %d", tt.size)
+		require.NoError(t, bp.Put(buf))
+	}
+
+	_, err := bp.Get(-1)
+	assert.ErrorIs(t, err, bufpool.ErrInvalidSize)
+}
+
+func TestBufPoolPutReturnsToClass(t *testing.T) {
+	bp := createBufPool(t, 64, 256)
+	defer func() {
+		require.NoError(t, bp.Close())
+	}()
+
+	buf, err := bp.Get(64)
+	require.NoError(t, err)
+	copy(*buf, "hello")
+
+	require.NoError(t, bp.Put(buf))
+	assert.Empty(t, *buf)
+
+	stats := bp.Stats()
+	require.Len(t, stats, 3)
+	assert.Equal(t, 64, stats[0].Size)
+	assert.Equal(t, uint64(1), stats[0].TotalGets)
+	assert.Equal(t, uint64(0), stats[0].ObjectsInUse)
+	assert.Equal(t, uint64(0), stats[1].TotalGets)
+
+	reused, err := bp.Get(5)
+	require.NoError(t, err)
+	assert.Len(t, *reused, 5)
+	require.NoError(t, bp.Put(reused))
+
+	for _, s := range bp.Stats() {
+		assert.Equal(t, s.TotalGets, s.FastReturnHit+s.FastReturnMiss, "class %d", s.Size)
+		assert.Equal(t, uint64(0), s.ObjectsInUse, "class %d", s.Size)
+	}
+}
+
+func TestBufPoolPutRoutesByCapacity(t *testing.T) {
+	bp := createBufPool(t, 64, 256)
+	defer func() {
+		require.NoError(t, bp.Close())
+	}()
+
+	returns := func() []uint64 {
+		var counts []uint64
+		for _, s := range bp.Stats() {
+			counts = append(counts, s.FastReturnHit+s.FastReturnMiss)
+		}
+		return counts
+	}
+
+	// Buffers go to the largest class they can hold, whoever allocated them.
+	exact := make([]byte, 10, 128)
+	require.NoError(t, bp.Put(&exact))
+	assert.Empty(t, exact)
+
+	between := make([]byte, 0, 200)
+	require.NoError(t, bp.Put(&between))
+	assert.Equal(t, []uint64{0, 2, 0}, returns())
+
+	largest := make([]byte, 0, 256)
+	require.NoError(t, bp.Put(&largest))
+	assert.Equal(t, []uint64{0, 2, 1}, returns())
+
+	// Buffers outside the size classes are dropped.
+	undersized := make([]byte, 0, 32)
+	require.NoError(t, bp.Put(&undersized))
+
+	oversized := make([]byte, 0, 4096)
+	require.NoError(t, bp.Put(&oversized))
+
+	unpooled, err := bp.Get(4096)
+	require.NoError(t, err)
+	require.NoError(t, bp.Put(unpooled))
+
+	assert.Equal(t, []uint64{0, 2, 1}, returns())
+}
+
+func TestBufPoolInvalidSizes(t *testing.T) {
+	_, err := bufpool.New(0, 1024, nil)
+	assert.ErrorIs(t, err, bufpool.ErrInvalidSize)
+
+	_, err = bufpool.New(1024, 64, nil)
+	assert.ErrorIs(t, err, bufpool.ErrInvalidSize)
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/code_examples/configs"
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+)
+
+// presets maps the names accepted by -config to the example workload configurations.
+var presets = map[string]func() *pool.PoolConfig[*configs.Example]{
+	"high-throughput":    configs.CreateHighThroughputConfig,
+	"memory-constrained": configs.CreateMemoryConstrainedConfig,
+	"low-latency":        configs.CreateLowLatencyConfig,
+	"batch-processing":   configs.CreateBatchProcessingConfig,
+	"real-time":          configs.CreateRealTimeConfig,
+	"balanced":           configs.CreateBalancedConfig,
+}
+
+// namedConfig is a configuration to simulate along with the name it's reported under.
+type namedConfig struct {
+	name   string
+	config *pool.PoolConfig[*configs.Example]
+}
+
+// duration is a time.Duration read from a JSON string such as "5s".
+type duration struct {
+	time.Duration
+}
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"5s\": %w", err)
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	d.Duration = parsed
+	return nil
+}
+
+// configSpec is the JSON description of a pool configuration. Each section maps to the
+// builder's bulk method of the same name, omitted sections keep the defaults.
+type configSpec struct {
+	Name                string `json:"name"`
+	InitialCapacity     int    `json:"initialCapacity"`
+	HardLimit           int    `json:"hardLimit"`
+	EnableChannelGrowth bool   `json:"enableChannelGrowth"`
+
+	RingBuffer *struct {
+		Block   bool     `json:"block"`
+		Timeout duration `json:"timeout"`
+	} `json:"ringBuffer"`
+
+	Growth *growthSpec `json:"growth"`
+
+	Shrink *struct {
+		CheckInterval                duration `json:"checkInterval"`
+		ShrinkCooldown               duration `json:"shrinkCooldown"`
+		StableUnderutilizationRounds int      `json:"stableUnderutilizationRounds"`
+		MinCapacity                  int      `json:"minCapacity"`
+		MaxConsecutiveShrinks        int      `json:"maxConsecutiveShrinks"`
+		MinUtilizationBeforeShrink   int      `json:"minUtilizationBeforeShrink"`
+		ShrinkPercent                int      `json:"shrinkPercent"`
+	} `json:"shrink"`
+
+	FastPath *struct {
+		InitialSize         int `json:"initialSize"`
+		GrowthEventsTrigger int `json:"growthEventsTrigger"`
+		ShrinkEventsTrigger int `json:"shrinkEventsTrigger"`
+		FillAggressiveness  int `json:"fillAggressiveness"`
+		RefillPercent       int `json:"refillPercent"`
+	} `json:"fastPath"`
+
+	FastPathGrowth *growthSpec `json:"fastPathGrowth"`
+
+	FastPathShrink *struct {
+		ShrinkPercent int `json:"shrinkPercent"`
+		MinCapacity   int `json:"minCapacity"`
+	} `json:"fastPathShrink"`
+
+	Allocation *struct {
+		AllocPercent int `json:"allocPercent"`
+		AllocAmount  int `json:"allocAmount"`
+	} `json:"allocation"`
+}
+
+type growthSpec struct {
+	ThresholdFactor        float64 `json:"thresholdFactor"`
+	BigGrowthFactor        float64 `json:"bigGrowthFactor"`
+	ControlledGrowthFactor float64 `json:"controlledGrowthFactor"`
+}
+
+// build turns the spec into a validated pool configuration.
+func (s *configSpec) build() (*pool.PoolConfig[*configs.Example], error) {
+	b := pool.NewPoolConfigBuilder[*configs.Example]().
+		SetPoolBasicConfigs(s.InitialCapacity, s.HardLimit, s.EnableChannelGrowth)
+
+	if rb := s.RingBuffer; rb != nil {
+		b = b.SetRingBufferBasicConfigs(rb.Block, 0, 0, rb.Timeout.Duration)
+	}
+
+	if g := s.Growth; g != nil {
+		b = b.SetRingBufferGrowthConfigs(g.ThresholdFactor, g.BigGrowthFactor, g.ControlledGrowthFactor)
+	}
+
+	if sh := s.Shrink; sh != nil {
+		b = b.SetRingBufferShrinkConfigs(sh.CheckInterval.Duration, sh.ShrinkCooldown.Duration, sh.StableUnderutilizationRounds,
+			sh.MinCapacity, sh.MaxConsecutiveShrinks, sh.MinUtilizationBeforeShrink, sh.ShrinkPercent)
+	}
+
+	if fp := s.FastPath; fp != nil {
+		b = b.SetFastPathBasicConfigs(fp.InitialSize, fp.GrowthEventsTrigger, fp.ShrinkEventsTrigger, fp.FillAggressiveness, fp.RefillPercent)
+	}
+
+	if g := s.FastPathGrowth; g != nil {
+		b = b.SetFastPathGrowthConfigs(g.ThresholdFactor, g.BigGrowthFactor, g.ControlledGrowthFactor)
+	}
+
+	if fs := s.FastPathShrink; fs != nil {
+		b = b.SetFastPathShrinkConfigs(fs.ShrinkPercent, fs.MinCapacity)
+	}
+
+	if a := s.Allocation; a != nil {
+		b = b.SetAllocationStrategy(a.AllocPercent, a.AllocAmount)
+	}
+
+	return b.Build()
+}
+
+// loadConfigs resolves each -config value, a preset name or the path of a JSON spec.
+func loadConfigs(values []string) ([]namedConfig, error) {
+	var loaded []namedConfig
+
+	for _, value := range values {
+		if create, ok := presets[value]; ok {
+			loaded = append(loaded, namedConfig{name: value, config: create()})
+			continue
+		}
+
+		if !strings.HasSuffix(value, ".json") {
+			return nil, fmt.Errorf("unknown preset %q, expected one of %s or a .json file", value, strings.Join(presetNames(), ", "))
+		}
+
+		config, name, err := loadConfigFile(value)
+		if err != nil {
+			return nil, fmt.Errorf("config %s: %w", value, err)
+		}
+
+		loaded = append(loaded, namedConfig{name: name, config: config})
+	}
+
+	return loaded, nil
+}
+
+// loadConfigFile reads a JSON spec, naming it after the file unless it sets a name.
+func loadConfigFile(path string) (*pool.PoolConfig[*configs.Example], string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var spec configSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, "", err
+	}
+
+	config, err := spec.build()
+	if err != nil {
+		return nil, "", err
+	}
+
+	name := spec.Name
+	if name == "" {
+		name = path
+	}
+
+	return config, name, nil
+}
+
+func presetNames() []string {
+	return []string{"high-throughput", "memory-constrained", "low-latency", "batch-processing", "real-time", "balanced"}
+}
@@ -0,0 +1,142 @@
+// Command poolx-sim replays a synthetic or recorded workload against one or more pool
+// configurations and reports how each of them behaves, so configurations can be tuned offline.
+//
+// Usage:
+//
+//	poolx-sim -config balanced -config low-latency -rate 5000 -hold 2ms -duration 10s
+//	poolx-sim -config mine.json -workload recorded.csv
+//
+// Each -config is either the name of a preset from code_examples/configs or the path of a
+// JSON file describing the builder parameters. Recorded workloads are CSV files of
+// "offset,hold" records, see loadWorkload.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// stringList is a flag that can be repeated or given comma separated values.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*s = append(*s, v)
+		}
+	}
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "poolx-sim:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("poolx-sim", flag.ContinueOnError)
+
+	var configNames stringList
+	flags.Var(&configNames, "config", "preset name or JSON config file, repeat or comma separate to compare (presets: "+strings.Join(presetNames(), ", ")+")")
+	workloadPath := flags.String("workload", "", "recorded workload CSV of offset,hold records; overrides the synthetic workload flags")
+	duration := flags.Duration("duration", 5*time.Second, "length of the synthetic workload")
+	rate := flags.Float64("rate", 1000, "synthetic arrivals per second")
+	hold := flags.Duration("hold", 5*time.Millisecond, "mean hold time of the synthetic workload")
+	seed := flags.Int64("seed", 1, "seed of the synthetic workload, the same workload is replayed against every config")
+	sample := flags.Duration("sample", 10*time.Millisecond, "interval between statistics samples")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if len(configNames) == 0 {
+		return fmt.Errorf("at least one -config is required")
+	}
+
+	if *sample <= 0 {
+		return fmt.Errorf("-sample must be positive")
+	}
+
+	configs, err := loadConfigs(configNames)
+	if err != nil {
+		return err
+	}
+
+	workload, err := buildWorkload(*workloadPath, *duration, *rate, *hold, *seed)
+	if err != nil {
+		return err
+	}
+
+	if len(workload) == 0 {
+		return fmt.Errorf("workload is empty")
+	}
+
+	results := make([]result, 0, len(configs))
+	for _, config := range configs {
+		res, err := simulate(config, workload, *sample)
+		if err != nil {
+			return fmt.Errorf("simulating %s: %w", config.name, err)
+		}
+		results = append(results, res)
+	}
+
+	return printResults(out, results)
+}
+
+func buildWorkload(path string, duration time.Duration, rate float64, hold time.Duration, seed int64) ([]arrival, error) {
+	if path == "" {
+		if duration <= 0 || rate <= 0 || hold < 0 {
+			return nil, fmt.Errorf("-duration and -rate must be positive and -hold non-negative")
+		}
+		return syntheticWorkload(duration, rate, hold, seed), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return loadWorkload(f)
+}
+
+// printResults writes one column per configuration so they can be compared side by side.
+func printResults(out io.Writer, results []result) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	row := func(label string, value func(r result) string) {
+		fmt.Fprintf(w, "%s\t", label)
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t", value(r))
+		}
+		fmt.Fprintln(w)
+	}
+
+	row("config", func(r result) string { return r.name })
+	row("requests", func(r result) string { return fmt.Sprint(r.requests) })
+	row("failures", func(r result) string { return fmt.Sprint(r.failures) })
+	row("L2 spill rate", func(r result) string { return fmt.Sprintf("%.2f%%", r.spillRate*100) })
+	row("growth events", func(r result) string { return fmt.Sprint(r.growthEvents) })
+	row("shrink events", func(r result) string { return fmt.Sprint(r.shrinkEvents) })
+	row("objects created", func(r result) string { return fmt.Sprint(r.created) })
+	row("peak in use", func(r result) string { return fmt.Sprint(r.peakInUse) })
+	row("peak live objects", func(r result) string { return fmt.Sprint(r.peakLive) })
+	row("peak capacity", func(r result) string { return fmt.Sprint(r.peakCapacity) })
+	row("wait p50", func(r result) string { return r.waitP50.String() })
+	row("wait p99", func(r result) string { return r.waitP99.String() })
+	row("wait max", func(r result) string { return r.waitMax.String() })
+	row("elapsed", func(r result) string { return r.elapsed.Round(time.Millisecond).String() })
+
+	return w.Flush()
+}
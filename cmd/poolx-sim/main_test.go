@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadWorkload(t *testing.T) {
+	input := `# offset,hold
+2ms,1ms
+0,500us
+1.5,3
+`
+	arrivals, err := loadWorkload(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, []arrival{
+		{offset: 0, hold: 500 * time.Microsecond},
+		{offset: 1500 * time.Microsecond, hold: 3 * time.Millisecond},
+		{offset: 2 * time.Millisecond, hold: time.Millisecond},
+	}, arrivals)
+
+	_, err = loadWorkload(strings.NewReader("1ms,-1ms\n"))
+	assert.Error(t, err)
+
+	_, err = loadWorkload(strings.NewReader("soon,1ms\n"))
+	assert.Error(t, err)
+}
+
+func TestSyntheticWorkload(t *testing.T) {
+	first := syntheticWorkload(time.Second, 1000, time.Millisecond, 7)
+	second := syntheticWorkload(time.Second, 1000, time.Millisecond, 7)
+
+	assert.Equal(t, first, second, "the same seed must produce the same workload")
+	assert.InDelta(t, 1000, len(first), 150)
+
+	for i := 1; i < len(first); i++ {
+		assert.GreaterOrEqual(t, first[i].offset, first[i-1].offset)
+	}
+	assert.Less(t, first[len(first)-1].offset, time.Second)
+}
+
+func TestLoadConfigs(t *testing.T) {
+	configs, err := loadConfigs([]string{"balanced", "low-latency"})
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	assert.Equal(t, "balanced", configs[0].name)
+
+	_, err = loadConfigs([]string{"no-such-preset"})
+	assert.Error(t, err)
+}
+
+func TestRunComparesConfigs(t *testing.T) {
+	var out bytes.Buffer
+	err := run([]string{
+		"-config", "balanced,memory-constrained",
+		"-duration", "200ms",
+		"-rate", "500",
+		"-hold", "1ms",
+	}, &out)
+	require.NoError(t, err)
+
+	report := out.String()
+	assert.Contains(t, report, "balanced")
+	assert.Contains(t, report, "memory-constrained")
+	assert.Contains(t, report, "L2 spill rate")
+	assert.Contains(t, report, "wait p99")
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/code_examples/configs"
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+)
+
+// result holds the metrics of replaying a workload against one configuration.
+type result struct {
+	name     string
+	requests int
+	failures int
+
+	spillRate    float64
+	growthEvents int
+	shrinkEvents int
+	created      int
+
+	peakInUse    uint64
+	peakLive     int
+	peakCapacity int
+
+	waitP50 time.Duration
+	waitP99 time.Duration
+	waitMax time.Duration
+
+	elapsed time.Duration
+}
+
+// simulate replays the workload in real time against a new pool built from config. Every
+// arrival runs in its own goroutine, which gets an object, holds it and puts it back, while
+// the pool statistics are sampled every sampleInterval to record peaks.
+func simulate(named namedConfig, workload []arrival, sampleInterval time.Duration) (result, error) {
+	allocator := func() *configs.Example {
+		return &configs.Example{}
+	}
+
+	cleaner := func(obj *configs.Example) {
+		obj.Name = ""
+		obj.Data = obj.Data[:0]
+	}
+
+	p, err := pool.NewPool(named.config, allocator, cleaner, nil)
+	if err != nil {
+		return result{}, err
+	}
+	poolObj := p.(*pool.Pool[*configs.Example])
+
+	res := result{name: named.name, requests: len(workload)}
+
+	stopSampling := make(chan struct{})
+	samplingDone := make(chan struct{})
+	go func() {
+		defer close(samplingDone)
+		sampleStats(poolObj, sampleInterval, stopSampling, &res)
+	}()
+
+	waits := make([]time.Duration, len(workload))
+	var failures atomic.Int64
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for i, a := range workload {
+		if d := a.offset - time.Since(start); d > 0 {
+			time.Sleep(d)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			requested := time.Now()
+			obj, err := p.Get()
+			waits[i] = time.Since(requested)
+			if err != nil {
+				failures.Add(1)
+				return
+			}
+
+			time.Sleep(a.hold)
+
+			if err := p.Put(obj); err != nil {
+				failures.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	res.elapsed = time.Since(start)
+
+	close(stopSampling)
+	<-samplingDone
+
+	stats := poolObj.GetPoolStatsSnapshot()
+	res.failures = int(failures.Load())
+	res.spillRate = stats.L2SpillRate
+	res.growthEvents = stats.TotalGrowthEvents
+	res.shrinkEvents = stats.TotalShrinkEvents
+	res.created = stats.ObjectsCreated
+	res.waitP50, res.waitP99, res.waitMax = waitPercentiles(waits)
+
+	return res, p.Close()
+}
+
+// sampleStats records the peak objects in use, live objects and capacity until stop is closed.
+func sampleStats(p *pool.Pool[*configs.Example], interval time.Duration, stop <-chan struct{}, res *result) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		stats := p.GetPoolStatsSnapshot()
+		res.peakInUse = max(res.peakInUse, stats.ObjectsInUse)
+		res.peakLive = max(res.peakLive, stats.ObjectsCreated-stats.ObjectsDestroyed)
+		res.peakCapacity = max(res.peakCapacity, stats.CurrentCapacity)
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitPercentiles returns the median, 99th percentile and maximum of the Get wait times.
+func waitPercentiles(waits []time.Duration) (p50, p99, maxWait time.Duration) {
+	if len(waits) == 0 {
+		return 0, 0, 0
+	}
+
+	sorted := make([]time.Duration, len(waits))
+	copy(sorted, waits)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	percentile := func(p float64) time.Duration {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+
+	return percentile(0.50), percentile(0.99), sorted[len(sorted)-1]
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// arrival is a single request of a workload: when it arrives, relative to the start of the
+// replay, and how long it holds the object.
+type arrival struct {
+	offset time.Duration
+	hold   time.Duration
+}
+
+// syntheticWorkload generates Poisson arrivals at rate requests per second over duration,
+// with exponentially distributed hold times of mean meanHold.
+func syntheticWorkload(duration time.Duration, rate float64, meanHold time.Duration, seed int64) []arrival {
+	rng := rand.New(rand.NewSource(seed))
+
+	var arrivals []arrival
+	var offset time.Duration
+	for {
+		offset += time.Duration(rng.ExpFloat64() / rate * float64(time.Second))
+		if offset >= duration {
+			return arrivals
+		}
+
+		hold := time.Duration(rng.ExpFloat64() * float64(meanHold))
+		arrivals = append(arrivals, arrival{offset: offset, hold: hold})
+	}
+}
+
+// loadWorkload reads a recorded workload in CSV form, one "offset,hold" record per request.
+// Both fields are Go durations such as "1.5ms", or plain numbers of milliseconds.
+// Lines starting with # are ignored and records are sorted by offset.
+func loadWorkload(r io.Reader) ([]arrival, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var arrivals []arrival
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		offset, err := parseWorkloadDuration(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("record %d: offset: %w", line, err)
+		}
+
+		hold, err := parseWorkloadDuration(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("record %d: hold: %w", line, err)
+		}
+
+		arrivals = append(arrivals, arrival{offset: offset, hold: hold})
+	}
+
+	sort.Slice(arrivals, func(i, j int) bool {
+		return arrivals[i].offset < arrivals[j].offset
+	})
+
+	return arrivals, nil
+}
+
+func parseWorkloadDuration(field string) (time.Duration, error) {
+	field = strings.TrimSpace(field)
+
+	if ms, err := strconv.ParseFloat(field, 64); err == nil {
+		if ms < 0 {
+			return 0, fmt.Errorf("negative duration %q", field)
+		}
+		return time.Duration(ms * float64(time.Millisecond)), nil
+	}
+
+	d, err := time.ParseDuration(field)
+	if err != nil {
+		return 0, err
+	}
+
+	if d < 0 {
+		return 0, fmt.Errorf("negative duration %q", field)
+	}
+
+	return d, nil
+}
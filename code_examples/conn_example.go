@@ -15,6 +15,8 @@ type DBConnection struct {
 	LastUsed  time.Time
 }
 
+// DbConnectionExample pools connection-like objects directly. For real net.Conn connections,
+// the connpool package adds max open and idle limits, dialing, health checks and lifetimes.
 func DbConnectionExample() {
 	poolConfig, err := pool.NewPoolConfigBuilder[*DBConnection]().
 		SetPoolBasicConfigs(256, 3000, true).
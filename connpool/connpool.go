@@ -0,0 +1,454 @@
+// Package connpool provides a network connection pool with database/sql style semantics,
+// built on top of pool.Pool: a maximum number of open and idle connections, dialing with a
+// context, health checks and connection lifetimes.
+package connpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+)
+
+var (
+	// ErrInvalidConfig is returned by New when the configuration is invalid.
+	ErrInvalidConfig = errors.New("invalid connection pool config")
+
+	// ErrPoolClosed is returned by Get once the pool is closed.
+	ErrPoolClosed = errors.New("connection pool is closed")
+
+	// ErrDialFailed wraps the errors returned by the dialer.
+	ErrDialFailed = errors.New("dial failed")
+
+	errNilDialer = errors.New("dialer is nil")
+)
+
+// minReapInterval bounds how often the idle connections are checked, so very short idle
+// times don't turn the reaper into a busy loop.
+const minReapInterval = time.Millisecond
+
+// Dialer opens a new connection. ctx is the context passed to Get.
+type Dialer func(ctx context.Context) (net.Conn, error)
+
+// Config configures a connection pool. Zero values mean no limit, except for MaxOpen.
+type Config struct {
+	// MaxOpen is the maximum number of open connections, idle or in use. Get waits for a
+	// connection to be returned once it's reached.
+	MaxOpen int
+
+	// MaxIdle is the maximum number of idle connections kept open, connections returned
+	// above it are closed. Zero keeps up to MaxOpen idle connections.
+	MaxIdle int
+
+	// MaxLifetime is the maximum time a connection may be reused after it was dialed.
+	MaxLifetime time.Duration
+
+	// MaxIdleTime is the maximum time a connection may stay idle before it's closed. Idle
+	// connections past it or MaxLifetime are closed in the background, even while the pool
+	// isn't used.
+	MaxIdleTime time.Duration
+
+	// HealthCheck is called on idle connections before they're handed out, a connection
+	// failing it is closed and replaced. May be nil.
+	HealthCheck func(ctx context.Context, conn net.Conn) error
+
+	// Clock is the source of time for lifetimes, nil means the system clock.
+	Clock pool.Clock
+}
+
+// Stats holds the connection pool statistics.
+type Stats struct {
+	MaxOpen int
+
+	// OpenConnections is the number of open connections, InUse the ones checked out and
+	// Idle the ones waiting in the pool.
+	OpenConnections int
+	InUse           int
+	Idle            int
+
+	// WaitCount is the number of callers currently waiting for a connection.
+	WaitCount int
+
+	Dials      uint64
+	DialErrors uint64
+
+	// The number of connections closed because of MaxIdle, MaxIdleTime, MaxLifetime,
+	// a failed health check, or discarded by their holder.
+	MaxIdleClosed      uint64
+	MaxIdleTimeClosed  uint64
+	MaxLifetimeClosed  uint64
+	HealthCheckFailed  uint64
+	DiscardedByHolders uint64
+}
+
+// slot is the object stored in the underlying pool. There are exactly MaxOpen slots, each
+// holding at most one connection, so the pool limits enforce MaxOpen.
+type slot struct {
+	conn       net.Conn
+	dialedAt   time.Time
+	returnedAt time.Time
+}
+
+// ConnPool is a pool of network connections.
+type ConnPool struct {
+	slots  *pool.Pool[*slot]
+	dial   Dialer
+	config Config
+	clock  pool.Clock
+
+	closed atomic.Bool
+
+	// stopReaper stops the reaper, which closes reaperDone once it returned.
+	stopReaper chan struct{}
+	reaperDone chan struct{}
+
+	open atomic.Int64
+	idle atomic.Int64
+
+	dials              atomic.Uint64
+	dialErrors         atomic.Uint64
+	maxIdleClosed      atomic.Uint64
+	maxIdleTimeClosed  atomic.Uint64
+	maxLifetimeClosed  atomic.Uint64
+	healthCheckFailed  atomic.Uint64
+	discardedByHolders atomic.Uint64
+}
+
+// New creates a connection pool opening connections with dial. No connection is dialed
+// until the first Get.
+func New(dial Dialer, config Config) (*ConnPool, error) {
+	if dial == nil {
+		return nil, errNilDialer
+	}
+
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	if config.MaxIdle == 0 {
+		config.MaxIdle = config.MaxOpen
+	}
+
+	// The capacity never changes: growth stops at the hard limit and shrinking stops at
+	// the minimum capacity, so slots holding idle connections are never dropped.
+	builder := pool.NewPoolConfigBuilder[*slot]().
+		SetInitialCapacity(config.MaxOpen).
+		SetHardLimit(config.MaxOpen).
+		SetMinShrinkCapacity(config.MaxOpen).
+		SetFastPathInitialSize(config.MaxOpen).
+		SetRingBufferBlocking(true).
+		SetWaitQueueConfigs(0)
+	if config.Clock != nil {
+		builder = builder.SetClock(config.Clock)
+	}
+
+	poolConfig, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	allocator := func() *slot {
+		return &slot{}
+	}
+
+	// Connections stay in their slot while idle, there's nothing to clean.
+	cleaner := func(*slot) {}
+
+	slots, err := pool.NewPool(poolConfig, allocator, cleaner, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cp := &ConnPool{
+		slots:      slots.(*pool.Pool[*slot]),
+		dial:       dial,
+		config:     config,
+		clock:      poolConfig.GetClock(),
+		stopReaper: make(chan struct{}),
+		reaperDone: make(chan struct{}),
+	}
+
+	if interval := reapInterval(config); interval > 0 {
+		go cp.reap(interval)
+	} else {
+		close(cp.reaperDone)
+	}
+
+	return cp, nil
+}
+
+// reapInterval returns how often the idle connections are checked, half the shortest of
+// MaxIdleTime and MaxLifetime, or 0 if neither is set.
+func reapInterval(config Config) time.Duration {
+	shortest := config.MaxIdleTime
+	if shortest == 0 || (config.MaxLifetime > 0 && config.MaxLifetime < shortest) {
+		shortest = config.MaxLifetime
+	}
+
+	if shortest == 0 {
+		return 0
+	}
+	return max(shortest/2, minReapInterval)
+}
+
+func validateConfig(config Config) error {
+	if config.MaxOpen <= 0 {
+		return fmt.Errorf("%w: MaxOpen must be greater than 0, got %d", ErrInvalidConfig, config.MaxOpen)
+	}
+
+	if config.MaxIdle < 0 || config.MaxIdle > config.MaxOpen {
+		return fmt.Errorf("%w: MaxIdle must be between 0 and MaxOpen (%d), got %d", ErrInvalidConfig, config.MaxOpen, config.MaxIdle)
+	}
+
+	if config.MaxLifetime < 0 {
+		return fmt.Errorf("%w: MaxLifetime must be >= 0, got %v", ErrInvalidConfig, config.MaxLifetime)
+	}
+
+	if config.MaxIdleTime < 0 {
+		return fmt.Errorf("%w: MaxIdleTime must be >= 0, got %v", ErrInvalidConfig, config.MaxIdleTime)
+	}
+
+	return nil
+}
+
+// Get returns an idle connection, or dials a new one if none is usable and fewer than
+// MaxOpen are open. Otherwise it waits for a connection to be returned until ctx is done.
+// Idle connections past MaxLifetime or MaxIdleTime, or failing the health check, are closed
+// and replaced by a new one.
+func (cp *ConnPool) Get(ctx context.Context) (*Conn, error) {
+	if cp.closed.Load() {
+		return nil, ErrPoolClosed
+	}
+
+	s, err := cp.slots.GetWithPriority(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.conn != nil {
+		cp.idle.Add(-1)
+		cp.checkIdle(ctx, s)
+	}
+
+	if s.conn == nil {
+		if err := cp.dialSlot(ctx, s); err != nil {
+			cp.slots.Put(s)
+			return nil, err
+		}
+	}
+
+	return &Conn{Conn: s.conn, pool: cp, slot: s}, nil
+}
+
+// checkIdle closes the connection of s if it expired while idle or fails the health check.
+func (cp *ConnPool) checkIdle(ctx context.Context, s *slot) {
+	now := cp.clock.Now()
+
+	switch {
+	case cp.expired(s, now):
+		cp.maxLifetimeClosed.Add(1)
+	case cp.idleExpired(s, now):
+		cp.maxIdleTimeClosed.Add(1)
+	case cp.config.HealthCheck != nil && cp.config.HealthCheck(ctx, s.conn) != nil:
+		cp.healthCheckFailed.Add(1)
+	default:
+		return
+	}
+
+	cp.closeSlot(s)
+}
+
+// reap is a background goroutine that periodically closes the idle connections past
+// MaxIdleTime or MaxLifetime.
+func (cp *ConnPool) reap(interval time.Duration) {
+	defer close(cp.reaperDone)
+
+	ticker := cp.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cp.stopReaper:
+			return
+		case <-ticker.C():
+			cp.reapIdle()
+		}
+	}
+}
+
+// reapIdle checks out every available slot, closes the expired idle connections and puts
+// the slots back. Callers of Get wait for the slots to be put back meanwhile.
+func (cp *ConnPool) reapIdle() {
+	var taken []*slot
+	for {
+		s, ok := cp.slots.TryGet()
+		if !ok {
+			break
+		}
+		taken = append(taken, s)
+	}
+
+	now := cp.clock.Now()
+	for _, s := range taken {
+		if s.conn == nil {
+			continue
+		}
+
+		switch {
+		case cp.expired(s, now):
+			cp.maxLifetimeClosed.Add(1)
+		case cp.idleExpired(s, now):
+			cp.maxIdleTimeClosed.Add(1)
+		default:
+			continue
+		}
+
+		cp.idle.Add(-1)
+		cp.closeSlot(s)
+	}
+
+	for _, s := range taken {
+		cp.slots.Put(s)
+	}
+}
+
+// expired reports whether the connection of s outlived MaxLifetime.
+func (cp *ConnPool) expired(s *slot, now time.Time) bool {
+	return cp.config.MaxLifetime > 0 && now.Sub(s.dialedAt) > cp.config.MaxLifetime
+}
+
+// idleExpired reports whether the connection of s has been idle for longer than MaxIdleTime.
+func (cp *ConnPool) idleExpired(s *slot, now time.Time) bool {
+	return cp.config.MaxIdleTime > 0 && now.Sub(s.returnedAt) > cp.config.MaxIdleTime
+}
+
+func (cp *ConnPool) dialSlot(ctx context.Context, s *slot) error {
+	cp.dials.Add(1)
+
+	conn, err := cp.dial(ctx)
+	if err != nil {
+		cp.dialErrors.Add(1)
+		return fmt.Errorf("%w: %w", ErrDialFailed, err)
+	}
+
+	s.conn = conn
+	s.dialedAt = cp.clock.Now()
+	cp.open.Add(1)
+	return nil
+}
+
+// closeSlot closes the connection of s, leaving the slot empty.
+func (cp *ConnPool) closeSlot(s *slot) {
+	s.conn.Close()
+	s.conn = nil
+	cp.open.Add(-1)
+}
+
+// put returns s to the pool, closing its connection if it can't be reused.
+func (cp *ConnPool) put(s *slot, discard bool) error {
+	now := cp.clock.Now()
+
+	switch {
+	case discard:
+		cp.discardedByHolders.Add(1)
+		cp.closeSlot(s)
+	case cp.closed.Load():
+		cp.closeSlot(s)
+	case cp.expired(s, now):
+		cp.maxLifetimeClosed.Add(1)
+		cp.closeSlot(s)
+	case cp.idle.Add(1) > int64(cp.config.MaxIdle):
+		cp.idle.Add(-1)
+		cp.maxIdleClosed.Add(1)
+		cp.closeSlot(s)
+	default:
+		s.returnedAt = now
+	}
+
+	return cp.slots.Put(s)
+}
+
+// Stats returns the connection pool statistics.
+func (cp *ConnPool) Stats() Stats {
+	open := int(cp.open.Load())
+	idle := int(cp.idle.Load())
+
+	return Stats{
+		MaxOpen:            cp.config.MaxOpen,
+		OpenConnections:    open,
+		InUse:              open - idle,
+		Idle:               idle,
+		WaitCount:          cp.slots.Waiters(),
+		Dials:              cp.dials.Load(),
+		DialErrors:         cp.dialErrors.Load(),
+		MaxIdleClosed:      cp.maxIdleClosed.Load(),
+		MaxIdleTimeClosed:  cp.maxIdleTimeClosed.Load(),
+		MaxLifetimeClosed:  cp.maxLifetimeClosed.Load(),
+		HealthCheckFailed:  cp.healthCheckFailed.Load(),
+		DiscardedByHolders: cp.discardedByHolders.Load(),
+	}
+}
+
+// Close stops the reaper and closes the idle connections and the underlying pool.
+// Connections still checked out are closed when they're returned, and, like pool.Pool,
+// Close waits for a while for them to be returned.
+func (cp *ConnPool) Close() error {
+	if !cp.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	// The reaper must be done with the slots it holds before they're drained.
+	close(cp.stopReaper)
+	<-cp.reaperDone
+
+	var drained []*slot
+	for {
+		s, ok := cp.slots.TryGet()
+		if !ok {
+			break
+		}
+		drained = append(drained, s)
+	}
+
+	for _, s := range drained {
+		if s.conn != nil {
+			cp.idle.Add(-1)
+			cp.closeSlot(s)
+		}
+		cp.slots.Put(s)
+	}
+
+	return cp.slots.Close()
+}
+
+// Conn is a connection checked out from a ConnPool. Close returns it to the pool instead
+// of closing it, Discard closes it for good, e.g. after an I/O error left it unusable.
+type Conn struct {
+	net.Conn
+
+	pool     *ConnPool
+	slot     *slot
+	released atomic.Bool
+}
+
+// Close returns the connection to the pool. It returns net.ErrClosed if the connection
+// was already returned or discarded.
+func (c *Conn) Close() error {
+	if !c.released.CompareAndSwap(false, true) {
+		return net.ErrClosed
+	}
+	return c.pool.put(c.slot, false)
+}
+
+// Discard closes the connection instead of returning it to the pool, freeing its slot for
+// a new connection. It returns net.ErrClosed if the connection was already returned or discarded.
+func (c *Conn) Discard() error {
+	if !c.released.CompareAndSwap(false, true) {
+		return net.ErrClosed
+	}
+	return c.pool.put(c.slot, true)
+}
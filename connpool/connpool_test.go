@@ -0,0 +1,434 @@
+package connpool_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/connpool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pipeDialer dials net.Pipe connections and keeps track of the ones it opened.
+type pipeDialer struct {
+	mu    sync.Mutex
+	conns []*pipeConn
+	err   error
+}
+
+type pipeConn struct {
+	net.Conn
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func (c *pipeConn) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	return c.Conn.Close()
+}
+
+func (c *pipeConn) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (d *pipeDialer) dial(ctx context.Context) (net.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	client, server := net.Pipe()
+	server.Close()
+
+	conn := &pipeConn{Conn: client}
+	d.conns = append(d.conns, conn)
+	return conn, nil
+}
+
+func (d *pipeDialer) setErr(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.err = err
+}
+
+func (d *pipeDialer) closedConns() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	closed := 0
+	for _, conn := range d.conns {
+		if conn.isClosed() {
+			closed++
+		}
+	}
+	return closed
+}
+
+func newTestClock() *pooltest.FakeClock {
+	return pooltest.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func createConnPool(t *testing.T, dialer *pipeDialer, config connpool.Config) *connpool.ConnPool {
+	cp, err := connpool.New(dialer.dial, config)
+	require.NoError(t, err)
+	return cp
+}
+
+func TestConnPoolReusesIdleConnections(t *testing.T) {
+	dialer := &pipeDialer{}
+	cp := createConnPool(t, dialer, connpool.Config{MaxOpen: 2})
+	defer func() {
+		require.NoError(t, cp.Close())
+	}()
+
+	assert.Equal(t, uint64(0), cp.Stats().Dials, "connections are dialed lazily")
+
+	conn, err := cp.Get(context.Background())
+	require.NoError(t, err)
+	first := conn.Conn
+
+	stats := cp.Stats()
+	assert.Equal(t, 1, stats.OpenConnections)
+	assert.Equal(t, 1, stats.InUse)
+	require.NoError(t, conn.Close())
+	assert.ErrorIs(t, conn.Close(), net.ErrClosed)
+
+	stats = cp.Stats()
+	assert.Equal(t, 1, stats.Idle)
+	assert.Equal(t, 0, stats.InUse)
+
+	conn, err = cp.Get(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, first, conn.Conn)
+	assert.Equal(t, uint64(1), cp.Stats().Dials)
+	require.NoError(t, conn.Close())
+}
+
+func TestConnPoolMaxOpen(t *testing.T) {
+	dialer := &pipeDialer{}
+	cp := createConnPool(t, dialer, connpool.Config{MaxOpen: 2})
+	defer func() {
+		require.NoError(t, cp.Close())
+	}()
+
+	first, err := cp.Get(context.Background())
+	require.NoError(t, err)
+	second, err := cp.Get(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = cp.Get(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	got := make(chan *connpool.Conn)
+	go func() {
+		conn, err := cp.Get(context.Background())
+		assert.NoError(t, err)
+		got <- conn
+	}()
+
+	require.Eventually(t, func() bool { return cp.Stats().WaitCount == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, first.Close())
+
+	select {
+	case conn := <-got:
+		assert.Same(t, first.Conn, conn.Conn)
+		require.NoError(t, conn.Close())
+	case <-time.After(time.Second):
+		t.Fatal("waiting Get wasn't handed the returned connection")
+	}
+
+	require.NoError(t, second.Close())
+	assert.Equal(t, uint64(2), cp.Stats().Dials)
+}
+
+func TestConnPoolMaxIdle(t *testing.T) {
+	dialer := &pipeDialer{}
+	cp := createConnPool(t, dialer, connpool.Config{MaxOpen: 4, MaxIdle: 1})
+	defer func() {
+		require.NoError(t, cp.Close())
+	}()
+
+	var conns []*connpool.Conn
+	for range 3 {
+		conn, err := cp.Get(context.Background())
+		require.NoError(t, err)
+		conns = append(conns, conn)
+	}
+
+	for _, conn := range conns {
+		require.NoError(t, conn.Close())
+	}
+
+	stats := cp.Stats()
+	assert.Equal(t, 1, stats.Idle)
+	assert.Equal(t, 1, stats.OpenConnections)
+	assert.Equal(t, uint64(2), stats.MaxIdleClosed)
+	assert.Equal(t, 2, dialer.closedConns())
+}
+
+func TestConnPoolLifetimes(t *testing.T) {
+	clock := newTestClock()
+	dialer := &pipeDialer{}
+	cp := createConnPool(t, dialer, connpool.Config{
+		MaxOpen:     2,
+		MaxLifetime: time.Minute,
+		MaxIdleTime: 10 * time.Second,
+		Clock:       clock,
+	})
+	defer func() {
+		require.NoError(t, cp.Close())
+	}()
+
+	conn, err := cp.Get(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	clock.Advance(11 * time.Second)
+
+	conn, err = cp.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), cp.Stats().MaxIdleTimeClosed)
+	assert.Equal(t, uint64(2), cp.Stats().Dials, "the connection idle for too long was replaced")
+
+	// Returned past its lifetime, the connection is closed right away.
+	clock.Advance(2 * time.Minute)
+	require.NoError(t, conn.Close())
+
+	stats := cp.Stats()
+	assert.Equal(t, uint64(1), stats.MaxLifetimeClosed)
+	assert.Equal(t, 0, stats.OpenConnections)
+	assert.Equal(t, 2, dialer.closedConns())
+}
+
+func TestConnPoolReapsIdleConnections(t *testing.T) {
+	clock := newTestClock()
+	dialer := &pipeDialer{}
+	cp := createConnPool(t, dialer, connpool.Config{
+		MaxOpen:     3,
+		MaxIdleTime: 10 * time.Second,
+		Clock:       clock,
+	})
+	defer func() {
+		require.NoError(t, cp.Close())
+	}()
+
+	first, err := cp.Get(context.Background())
+	require.NoError(t, err)
+	second, err := cp.Get(context.Background())
+	require.NoError(t, err)
+	held, err := cp.Get(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, first.Close())
+	require.NoError(t, second.Close())
+
+	// The shrinker of the underlying pool and the reaper.
+	require.True(t, clock.WaitForTickers(2, time.Second))
+
+	// No Get is made, the reaper closes the idle connections on its own.
+	clock.Advance(16 * time.Second)
+
+	require.Eventually(t, func() bool {
+		return dialer.closedConns() == 2
+	}, time.Second, time.Millisecond)
+
+	stats := cp.Stats()
+	assert.Equal(t, uint64(2), stats.MaxIdleTimeClosed)
+	assert.Equal(t, 1, stats.OpenConnections, "the connection in use is left alone")
+	assert.Equal(t, 0, stats.Idle)
+	assert.False(t, held.Conn.(*pipeConn).isClosed())
+	require.NoError(t, held.Close())
+}
+
+func TestConnPoolHealthCheck(t *testing.T) {
+	dialer := &pipeDialer{}
+	var healthy sync.Map
+
+	cp := createConnPool(t, dialer, connpool.Config{
+		MaxOpen: 1,
+		HealthCheck: func(ctx context.Context, conn net.Conn) error {
+			if _, ok := healthy.Load(conn); ok {
+				return nil
+			}
+			return errors.New("connection reset")
+		},
+	})
+	defer func() {
+		require.NoError(t, cp.Close())
+	}()
+
+	conn, err := cp.Get(context.Background())
+	require.NoError(t, err)
+	first := conn.Conn
+	require.NoError(t, conn.Close())
+
+	conn, err = cp.Get(context.Background())
+	require.NoError(t, err)
+	assert.NotSame(t, first, conn.Conn, "the unhealthy connection was replaced")
+	assert.Equal(t, uint64(1), cp.Stats().HealthCheckFailed)
+
+	healthy.Store(conn.Conn, struct{}{})
+	second := conn.Conn
+	require.NoError(t, conn.Close())
+
+	conn, err = cp.Get(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, second, conn.Conn)
+	require.NoError(t, conn.Close())
+}
+
+func TestConnPoolDialFailure(t *testing.T) {
+	dialer := &pipeDialer{}
+	dialer.setErr(errors.New("connection refused"))
+	cp := createConnPool(t, dialer, connpool.Config{MaxOpen: 1})
+	defer func() {
+		require.NoError(t, cp.Close())
+	}()
+
+	_, err := cp.Get(context.Background())
+	assert.ErrorIs(t, err, connpool.ErrDialFailed)
+	assert.Equal(t, uint64(1), cp.Stats().DialErrors)
+
+	// The failed dial doesn't hold on to the only slot.
+	dialer.setErr(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	conn, err := cp.Get(ctx)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
+
+func TestConnPoolDiscard(t *testing.T) {
+	dialer := &pipeDialer{}
+	cp := createConnPool(t, dialer, connpool.Config{MaxOpen: 1})
+	defer func() {
+		require.NoError(t, cp.Close())
+	}()
+
+	conn, err := cp.Get(context.Background())
+	require.NoError(t, err)
+	first := conn.Conn
+
+	require.NoError(t, conn.Discard())
+	assert.ErrorIs(t, conn.Discard(), net.ErrClosed)
+	assert.Equal(t, 1, dialer.closedConns())
+
+	stats := cp.Stats()
+	assert.Equal(t, 0, stats.OpenConnections)
+	assert.Equal(t, uint64(1), stats.DiscardedByHolders)
+
+	conn, err = cp.Get(context.Background())
+	require.NoError(t, err)
+	assert.NotSame(t, first, conn.Conn)
+	require.NoError(t, conn.Close())
+}
+
+func TestConnPoolClose(t *testing.T) {
+	dialer := &pipeDialer{}
+	cp := createConnPool(t, dialer, connpool.Config{MaxOpen: 4})
+
+	idle, err := cp.Get(context.Background())
+	require.NoError(t, err)
+	held, err := cp.Get(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, idle.Close())
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		assert.NoError(t, held.Close())
+	}()
+
+	require.NoError(t, cp.Close())
+
+	// The idle connection is closed by Close, the held one when it's returned.
+	assert.Equal(t, 2, dialer.closedConns())
+	assert.Equal(t, 0, cp.Stats().OpenConnections)
+
+	_, err = cp.Get(context.Background())
+	assert.ErrorIs(t, err, connpool.ErrPoolClosed)
+}
+
+func TestConnPoolLocalListener(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					conn.Write([]byte(scanner.Text() + "\n"))
+				}
+			}()
+		}
+	}()
+
+	var dialer net.Dialer
+	cp, err := connpool.New(func(ctx context.Context) (net.Conn, error) {
+		return dialer.DialContext(ctx, "tcp", listener.Addr().String())
+	}, connpool.Config{MaxOpen: 2})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, cp.Close())
+	}()
+
+	for range 3 {
+		conn, err := cp.Get(context.Background())
+		require.NoError(t, err)
+
+		_, err = conn.Write([]byte("ping\n"))
+		require.NoError(t, err)
+
+		reply, err := bufio.NewReader(conn).ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "ping\n", reply)
+
+		require.NoError(t, conn.Close())
+	}
+
+	assert.Equal(t, uint64(1), cp.Stats().Dials)
+}
+
+func TestConnPoolConfig(t *testing.T) {
+	dialer := &pipeDialer{}
+
+	invalid := map[string]connpool.Config{
+		"zero max open":       {},
+		"max idle above open": {MaxOpen: 1, MaxIdle: 2},
+		"negative max idle":   {MaxOpen: 1, MaxIdle: -1},
+		"negative lifetime":   {MaxOpen: 1, MaxLifetime: -time.Second},
+		"negative idle time":  {MaxOpen: 1, MaxIdleTime: -time.Second},
+	}
+
+	for name, config := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := connpool.New(dialer.dial, config)
+			assert.ErrorIs(t, err, connpool.ErrInvalidConfig)
+		})
+	}
+
+	_, err := connpool.New(nil, connpool.Config{MaxOpen: 1})
+	assert.Error(t, err)
+}
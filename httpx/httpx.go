@@ -0,0 +1,47 @@
+// Package httpx integrates pool.Pool with net/http, checking a request-scoped object out of
+// a pool for every request and returning it once the handler is done.
+package httpx
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+)
+
+// contextKey is the key of the pooled object in the request context. It's parameterized by
+// the object type, so middlewares of pools with different types don't overwrite each other.
+type contextKey[T any] struct{}
+
+// Middleware returns a middleware that gets an object from p for every request, attaches
+// it to the request context, where From retrieves it, and puts it back when the handler
+// returns, even if it panics. Handlers must not keep the object after they return.
+//
+// Requests for which Get fails, e.g. because the pool is exhausted and non-blocking, are
+// answered with 503 Service Unavailable without calling the handler.
+func Middleware[T any](p pool.PoolObj[T]) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			obj, err := p.Get()
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+			defer p.Put(obj)
+
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), obj)))
+		})
+	}
+}
+
+// NewContext returns a copy of ctx carrying obj, for handlers that check objects out themselves
+// or tests calling handlers directly.
+func NewContext[T any](ctx context.Context, obj T) context.Context {
+	return context.WithValue(ctx, contextKey[T]{}, obj)
+}
+
+// From returns the object attached to ctx by Middleware, or false if there's none of type T.
+func From[T any](ctx context.Context) (T, bool) {
+	obj, ok := ctx.Value(contextKey[T]{}).(T)
+	return obj, ok
+}
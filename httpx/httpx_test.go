@@ -0,0 +1,128 @@
+package httpx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AlexsanderHamir/PoolX/v2/httpx"
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type scratch struct {
+	buf []byte
+}
+
+func createScratchPool(t *testing.T) *pool.Pool[*scratch] {
+	config, err := pool.NewPoolConfigBuilder[*scratch]().
+		SetInitialCapacity(1).
+		SetHardLimit(1).
+		SetMinShrinkCapacity(1).
+		SetFastPathInitialSize(1).
+		SetRingBufferBlocking(false).
+		Build()
+	require.NoError(t, err)
+
+	p, err := pool.NewPool(config,
+		func() *scratch { return &scratch{buf: make([]byte, 0, 64)} },
+		func(s *scratch) { s.buf = s.buf[:0] },
+		nil,
+	)
+	require.NoError(t, err)
+
+	return p.(*pool.Pool[*scratch])
+}
+
+func TestMiddleware(t *testing.T) {
+	p := createScratchPool(t)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	var seen []*scratch
+	handler := httpx.Middleware[*scratch](p)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s, ok := httpx.From[*scratch](r.Context())
+		require.True(t, ok)
+		assert.Empty(t, s.buf, "objects are cleaned between requests")
+
+		s.buf = append(s.buf, "hello"...)
+		seen = append(seen, s)
+		w.Write(s.buf)
+	}))
+
+	for range 2 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "hello", rec.Body.String())
+		assert.Equal(t, uint64(0), p.GetPoolStatsSnapshot().ObjectsInUse)
+	}
+
+	require.Len(t, seen, 2)
+	assert.Same(t, seen[0], seen[1], "the object is returned after each request")
+}
+
+func TestMiddlewareReturnsObjectOnPanic(t *testing.T) {
+	p := createScratchPool(t)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	handler := httpx.Middleware[*scratch](p)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("handler failed")
+	}))
+
+	assert.Panics(t, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.Equal(t, uint64(0), p.GetPoolStatsSnapshot().ObjectsInUse)
+
+	obj, err := p.Get()
+	require.NoError(t, err, "the object is available again")
+	require.NoError(t, p.Put(obj))
+}
+
+func TestMiddlewarePoolExhausted(t *testing.T) {
+	p := createScratchPool(t)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	held, err := p.Get()
+	require.NoError(t, err)
+
+	called := false
+	handler := httpx.Middleware[*scratch](p)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.False(t, called)
+
+	require.NoError(t, p.Put(held))
+}
+
+func TestFrom(t *testing.T) {
+	_, ok := httpx.From[*scratch](context.Background())
+	assert.False(t, ok)
+
+	s := &scratch{}
+	ctx := httpx.NewContext(context.Background(), s)
+	ctx = httpx.NewContext(ctx, []int{1, 2})
+
+	got, ok := httpx.From[*scratch](ctx)
+	require.True(t, ok)
+	assert.Same(t, s, got, "objects of different types don't overwrite each other")
+
+	ints, ok := httpx.From[[]int](ctx)
+	require.True(t, ok)
+	assert.Equal(t, []int{1, 2}, ints)
+}
@@ -0,0 +1,95 @@
+package pool
+
+// fastPathCounters holds the fast path counters at the start of an adaptive sizing window.
+type fastPathCounters struct {
+	returnHits   uint64
+	returnMisses uint64
+	gets         uint64
+	getMisses    uint64
+}
+
+func (p *Pool[T]) readFastPathCounters() fastPathCounters {
+	return fastPathCounters{
+		returnHits:   p.stats.FastReturnHit.Load(),
+		returnMisses: p.stats.FastReturnMiss.Load(),
+		gets:         p.stats.totalGets.Load(),
+		getMisses:    p.stats.l1GetMisses.Load(),
+	}
+}
+
+// missRateSince returns the higher of the L2 spill rate of Put and the L1 miss rate of Get
+// since start. Returns false if the pool had no traffic in between.
+func (c fastPathCounters) missRateSince(start fastPathCounters) (float64, bool) {
+	returnHits := c.returnHits - start.returnHits
+	returnMisses := c.returnMisses - start.returnMisses
+	gets := c.gets - start.gets
+	getMisses := c.getMisses - start.getMisses
+
+	returns := returnHits + returnMisses
+	if returns == 0 && gets == 0 {
+		return 0, false
+	}
+
+	var spillRate, getMissRate float64
+	if returns > 0 {
+		spillRate = float64(returnMisses) / float64(returns)
+	}
+
+	if gets > 0 {
+		getMissRate = min(float64(getMisses)/float64(gets), 1)
+	} else if getMisses > 0 {
+		getMissRate = 1
+	}
+
+	return max(spillRate, getMissRate), true
+}
+
+// adaptFastPath is a background goroutine that resizes the L1 cache at the end of every
+// window, based on how often the fast path missed during it. It replaces the growth and
+// shrink event triggers of the L1 cache when the adaptive mode is enabled.
+func (p *Pool[T]) adaptFastPath() {
+	params := p.config.adaptiveFastPath
+	ticker := p.config.clock.NewTicker(params.window)
+	defer ticker.Stop()
+
+	start := p.readFastPathCounters()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		end := p.readFastPathCounters()
+		missRate, ok := end.missRateSince(start)
+		start = end
+		if !ok {
+			continue
+		}
+
+		p.mu.Lock()
+		if newCap := p.adaptiveFastPathTarget(missRate); newCap != p.stats.currentL1Capacity {
+			p.resizeL1(newCap)
+		}
+		p.mu.Unlock()
+	}
+}
+
+// adaptiveFastPathTarget returns the L1 capacity for the measured miss rate. L1 grows with the
+// fast path growth factors above growMissRate, shrinks by the fast path shrink percent below
+// shrinkMissRate, and always stays within the configured bounds.
+func (p *Pool[T]) adaptiveFastPathTarget(missRate float64) int {
+	params := p.config.adaptiveFastPath
+	currentCap := p.stats.currentL1Capacity
+
+	switch {
+	case missRate > params.growMissRate && currentCap < params.maxSize:
+		newCap := max(p.calculateNewCapacity(currentCap), currentCap+1)
+		return min(newCap, params.maxSize)
+	case missRate < params.shrinkMissRate && currentCap > params.minSize:
+		newCap := currentCap * (100 - p.config.fastPath.shrink.shrinkPercent) / 100
+		return max(min(newCap, currentCap-1), params.minSize)
+	default:
+		return currentCap
+	}
+}
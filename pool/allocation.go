@@ -0,0 +1,210 @@
+package pool
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	errAllocationBackoff = errors.New("allocation paused after repeated failures")
+	errEmptyBatch        = errors.New("batch allocator returned no objects")
+)
+
+// newObject creates a new object, cloning the template when a cloner was provided
+// and calling the allocator otherwise. It doesn't update objectsCreated.
+// Allocator errors are wrapped with ErrAllocationFailed and counted towards the failure backoff.
+func (p *Pool[T]) newObject() (zero T, err error) {
+	obj, err := p.allocateObject()
+	if err != nil {
+		return zero, err
+	}
+
+	p.tracker.track(obj)
+	p.sampleSize(obj)
+	return obj, nil
+}
+
+// newObjects creates up to n objects with the batch allocator if one was configured, or
+// with newObject otherwise. The objects created before a failure are returned with the error.
+func (p *Pool[T]) newObjects(n int) ([]T, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	// The first object of a lazy pool is validated, so it doesn't come from a batch.
+	if p.config.allocBatch == nil || p.firstObjectPending.Load() {
+		objs := make([]T, 0, n)
+		for range n {
+			obj, err := p.newObject()
+			if err != nil {
+				return objs, err
+			}
+			objs = append(objs, obj)
+		}
+		return objs, nil
+	}
+
+	if p.isAllocationBackedOff() {
+		return nil, fmt.Errorf("%w: %w", ErrAllocationFailed, errAllocationBackoff)
+	}
+
+	batch := p.config.allocBatch(n)
+	objs := make([]T, 0, min(len(batch), n))
+	for _, obj := range batch[:min(len(batch), n)] {
+		if isNilObject(obj) {
+			continue
+		}
+
+		p.tracker.track(obj)
+		p.sampleSize(obj)
+		objs = append(objs, obj)
+	}
+
+	if len(objs) == 0 {
+		p.recordAllocationFailure()
+		return nil, fmt.Errorf("%w: %w", ErrAllocationFailed, errEmptyBatch)
+	}
+
+	p.allocFailures.Store(0)
+	return objs, nil
+}
+
+// preallocAmount returns how many objects the allocation mode preallocates for the given capacity.
+func (p *Pool[T]) preallocAmount(capacity int) int {
+	strategy := p.config.allocationStrategy
+
+	switch strategy.Mode {
+	case AllocationLazy:
+		return 0
+	case AllocationEager:
+		return capacity
+	default:
+		return capacity * strategy.AllocPercent / 100
+	}
+}
+
+// allocateObject runs the cloner or the allocator for newObject.
+func (p *Pool[T]) allocateObject() (zero T, err error) {
+	if p.firstObjectPending.Load() {
+		return p.allocateFirstObject()
+	}
+
+	if p.cloneTemplate != nil {
+		return p.cloneTemplate(p.template), nil
+	}
+
+	return p.callAllocator()
+}
+
+// allocateFirstObject allocates the first object of a lazy pool, validating it and creating
+// the template from it as newPool does for the other modes. Until it succeeds, every
+// allocation goes through it.
+func (p *Pool[T]) allocateFirstObject() (zero T, err error) {
+	p.firstObjectMu.Lock()
+	defer p.firstObjectMu.Unlock()
+
+	if !p.firstObjectPending.Load() {
+		return p.allocateObject()
+	}
+
+	obj, err := p.callAllocator()
+	if err != nil {
+		return zero, err
+	}
+
+	if err := p.validateObject(obj); err != nil {
+		p.discardInvalidObject(obj)
+		return zero, fmt.Errorf("%w: first object: %w", ErrAllocationFailed, err)
+	}
+
+	if p.cloneTemplate != nil {
+		p.template = p.cloneTemplate(obj)
+	}
+
+	p.firstObjectPending.Store(false)
+	return obj, nil
+}
+
+// discardInvalidObject cleans an allocated object that failed validation, so the resources
+// it holds are released. Nil objects, and objects of pools without a cleaner, are left as is.
+func (p *Pool[T]) discardInvalidObject(obj T) {
+	if p.cleaner == nil || isNilObject(obj) {
+		return
+	}
+
+	p.cleaner(obj)
+}
+
+// callAllocator runs the allocator, honoring and updating the failure backoff.
+func (p *Pool[T]) callAllocator() (zero T, err error) {
+	if p.isAllocationBackedOff() {
+		return zero, fmt.Errorf("%w: %w", ErrAllocationFailed, errAllocationBackoff)
+	}
+
+	obj, err := p.allocator(p.ctx)
+	if err != nil {
+		p.recordAllocationFailure()
+		return zero, fmt.Errorf("%w: %w", ErrAllocationFailed, err)
+	}
+
+	if isNilObject(obj) {
+		p.recordAllocationFailure()
+		return zero, fmt.Errorf("%w: %w", ErrAllocationFailed, errNilObject)
+	}
+
+	p.allocFailures.Store(0)
+	return obj, nil
+}
+
+// isAllocationBackedOff reports whether allocation is paused after repeated failures.
+func (p *Pool[T]) isAllocationBackedOff() bool {
+	until := p.allocBackoffUntil.Load()
+	if until == 0 {
+		return false
+	}
+
+	return p.config.clock.Now().UnixNano() < until
+}
+
+// recordAllocationFailure counts a failed allocation and pauses allocation for
+// FailureBackoff once FailureThreshold consecutive failures are reached.
+func (p *Pool[T]) recordAllocationFailure() {
+	strategy := p.config.allocationStrategy
+	failures := p.allocFailures.Add(1)
+
+	if strategy.FailureThreshold <= 0 || failures < int64(strategy.FailureThreshold) {
+		return
+	}
+
+	p.allocBackoffUntil.Store(p.config.clock.Now().Add(strategy.FailureBackoff).UnixNano())
+	p.allocFailures.Store(0)
+}
+
+// isNilObject reports whether obj can't be used as a pooled object: a nil interface, or a nil
+// pointer, map, channel or function. Other zero values, including nil slices and zero structs,
+// are valid objects for value pools.
+func isNilObject[T any](obj T) bool {
+	v := any(obj)
+	if v == nil {
+		return true
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func:
+		return rv.IsNil()
+	}
+
+	return false
+}
+
+// allocationError returns the first error caused by a failed allocation, or nil if there's none.
+func allocationError(errs ...error) error {
+	for _, err := range errs {
+		if errors.Is(err, ErrAllocationFailed) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -124,6 +124,93 @@ type PoolConfigBuilder[T any] interface {
 	//   - allocAmount: Amount of objects to create per request when L1 is empty
 	SetAllocationStrategy(allocPercent int, allocAmount int) PoolConfigBuilder[T]
 
+	// SetAllocationMode selects how many objects the pool allocates ahead of demand: a percentage
+	// of the capacity (the default), nothing until objects are requested, or the whole capacity.
+	SetAllocationMode(mode AllocationMode) PoolConfigBuilder[T]
+
+	// SetAllocBatch makes the pool create objects in bulk with allocBatch instead of calling the
+	// allocator once per object, for allocators that are cheaper in bulk, e.g. handing out the
+	// elements of a single backing array. allocBatch must return at most n objects.
+	//
+	// Note: A nil function is ignored, the allocator will be used instead.
+	SetAllocBatch(allocBatch func(n int) []T) PoolConfigBuilder[T]
+
+	// SetAllocationFailureBackoff pauses allocation after repeated allocator failures.
+	// Parameters:
+	//   - failureThreshold: Number of consecutive failures before allocation is paused
+	//   - backoff: How long allocation stays paused, Get returns ErrAllocationFailed meanwhile
+	//     unless an existing object is available
+	SetAllocationFailureBackoff(failureThreshold int, backoff time.Duration) PoolConfigBuilder[T]
+
+	// SetBackgroundFillConfigs enables a background goroutine that keeps ready objects available
+	// ahead of demand, running the allocator outside the pool lock.
+	// Parameters:
+	//   - lowWatermark: Number of ready objects (L1 + ring buffer) to keep available
+	//   - checkInterval: Time between checks, the filler is also woken up on L1 misses
+	//
+	// Note: A non-positive checkInterval is ignored, the default value will be used instead.
+	SetBackgroundFillConfigs(lowWatermark int, checkInterval time.Duration) PoolConfigBuilder[T]
+
+	// SetWaitQueueConfigs makes Get wait in a fair FIFO queue when no object is available,
+	// instead of blocking on the ring buffer.
+	// Parameters:
+	//   - maxWaiters: Maximum number of queued callers, zero means no limit. Callers beyond it
+	//     fail with ErrTooManyWaiters
+	//
+	// Note: Get waits in the queue even with a non-blocking ring buffer.
+	SetWaitQueueConfigs(maxWaiters int) PoolConfigBuilder[T]
+
+	// SetAdaptiveFastPathConfigs makes the L1 cache capacity adapt to the L2 spill rate of Put
+	// and the L1 miss rate of Get, measured every window, within minSize and maxSize.
+	// L1 grows when the higher rate is above growMissRate and shrinks when it's below
+	// shrinkMissRate, replacing the growth and shrink event triggers of the fast path.
+	//
+	// Note: Zero or negative values are ignored, default values will be used instead.
+	SetAdaptiveFastPathConfigs(minSize, maxSize int, window time.Duration, growMissRate, shrinkMissRate float64) PoolConfigBuilder[T]
+
+	// SetRetryPolicy configures how the slow paths of Get and Put retry failed ring buffer
+	// operations: no retries, a fixed delay, or an exponential delay with jitter, optionally
+	// bounded by a maximum elapsed time. Each retry is counted in the pool statistics.
+	SetRetryPolicy(policy RetryPolicy) PoolConfigBuilder[T]
+
+	// SetObjectTrackingConfigs makes the pool keep metadata for each object: its creation time,
+	// how many times it was handed out, its total hold time and, optionally, the last caller
+	// of Get. Objects handed out maxReuses times are retired by Put instead of being stored.
+	// Parameters:
+	//   - maxReuses: Number of checkouts after which an object is retired, zero means no limit
+	//   - captureCaller: Record the file and line of the caller of Get
+	//
+	// Note: Requires a pointer type, value pools can't tell their objects apart.
+	SetObjectTrackingConfigs(maxReuses int, captureCaller bool) PoolConfigBuilder[T]
+
+	// SetHoldTimeoutConfigs limits how long a caller may hold an object. Objects held longer
+	// than maxHoldTime are passed to onTimeout, along with the caller of Get that holds them.
+	// With reclaim, they also stop counting as in use and a replacement is allocated, so the
+	// pool recovers its capacity. Enables object tracking.
+	//
+	// Note: A non-positive checkInterval is ignored, the default value will be used instead.
+	SetHoldTimeoutConfigs(maxHoldTime, checkInterval time.Duration, reclaim bool, onTimeout func(obj T, holder string)) PoolConfigBuilder[T]
+
+	// SetInitialState makes new pools start from a state exported by ExportState, e.g. by the
+	// previous instance of a service, instead of the initial capacities, so they skip the
+	// growth the previous pool went through. Capacities are kept within the hard limit and the
+	// ring buffer above the minimum shrink capacity, zero capacities use the configured initial sizes.
+	SetInitialState(state PoolState) PoolConfigBuilder[T]
+
+	// SetHandleDebugConfigs enables the handle debug mode, meant for tests: objects released
+	// through a Handle are passed to poison and dropped instead of being returned to the pool,
+	// so code still using them after Release sees the poisoned state rather than racing with
+	// the object's next holder.
+	//
+	// Note: A nil poison function disables the debug mode.
+	SetHandleDebugConfigs(poison func(obj T)) PoolConfigBuilder[T]
+
+	// SetClock replaces the system clock used by the shrink, background fill and allocation
+	// backoff logic. The pooltest package provides a fake clock for deterministic tests.
+	//
+	// Note: A nil clock is ignored, the system clock will be used instead.
+	SetClock(clock Clock) PoolConfigBuilder[T]
+
 	// Single configuration methods
 	// These methods allow fine-grained control over individual parameters.
 	// Default values will be applied to unset parameters.
@@ -0,0 +1,108 @@
+package pool
+
+// backgroundFill is a background goroutine that keeps the configured number of ready objects
+// available. Objects are allocated without holding the pool lock and handed in through
+// setPoolAndBuffer, so expensive allocators don't stall Get callers.
+func (p *Pool[T]) backgroundFill() {
+	params := p.config.backgroundFill
+	ticker := p.config.clock.NewTicker(params.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C():
+		case <-p.fillSignal:
+		}
+
+		p.fillToLowWatermark(params.lowWatermark)
+	}
+}
+
+// notifyBackgroundFill wakes up the background filler without blocking.
+// It's a no-op when the filler is disabled.
+func (p *Pool[T]) notifyBackgroundFill() {
+	if !p.config.backgroundFill.enabled {
+		return
+	}
+
+	select {
+	case p.fillSignal <- struct{}{}:
+	default:
+	}
+}
+
+// fillToLowWatermark allocates enough objects to bring the ready objects up to lowWatermark.
+// The objects are reserved in objectsCreated before allocating, so concurrent on-demand
+// creation keeps respecting the ring buffer capacity.
+func (p *Pool[T]) fillToLowWatermark(lowWatermark int) {
+	toAdd := p.reserveBackgroundFill(lowWatermark)
+	if toAdd <= 0 {
+		return
+	}
+
+	objs, _ := p.newObjects(toAdd)
+	p.handInBackgroundFill(objs, toAdd)
+}
+
+// reserveBackgroundFill returns how many objects the filler should allocate and
+// reserves them in the pool statistics.
+func (p *Pool[T]) reserveBackgroundFill(lowWatermark int) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ready := len(*p.cacheL1) + p.pool.Length(false)
+	deficit := lowWatermark - ready
+
+	live := p.stats.objectsCreated - p.stats.objectsDestroyed
+	spaceAvailable := min(p.stats.currentCapacity, p.config.hardLimit) - live
+
+	toAdd := min(deficit, spaceAvailable)
+	if toAdd <= 0 {
+		return 0
+	}
+
+	p.stats.objectsCreated += toAdd
+	return toAdd
+}
+
+// handInBackgroundFill stores the allocated objects, preferring the L1 cache, and releases
+// the reservations of objects that failed to allocate. Objects that can't be stored,
+// e.g. after a concurrent shrink, are destroyed, see dropBackgroundFill.
+func (p *Pool[T]) handInBackgroundFill(objs []T, reserved int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stats.objectsCreated -= reserved - len(objs)
+
+	ch := *p.cacheL1
+	fastPathRemaining := cap(ch) - len(ch)
+
+	for i, obj := range objs {
+		if fastPathRemaining == 0 && p.pool.Free() == 0 {
+			p.dropBackgroundFill(objs[i:])
+			return
+		}
+
+		var err error
+		fastPathRemaining, err = p.setPoolAndBuffer(obj, fastPathRemaining)
+		if err != nil {
+			p.dropBackgroundFill(objs[i:])
+			return
+		}
+	}
+
+	p.refillCond.Broadcast()
+	p.waiters.wakeHead()
+}
+
+// dropBackgroundFill destroys allocated objects the pool has no room for: they stop being
+// tracked, are cleaned and counted in objectsDestroyed. Must be called with p.mu held.
+func (p *Pool[T]) dropBackgroundFill(objs []T) {
+	for _, obj := range objs {
+		p.tracker.forget(obj)
+		p.cleaner(obj)
+		p.stats.objectsDestroyed++
+	}
+}
@@ -0,0 +1,307 @@
+package pool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	errNilBudget       = errors.New("budget must not be nil")
+	errAlreadyInBudget = errors.New("pool already joined a budget")
+	errNilSizeOf       = errors.New("object size estimator must not be nil")
+
+	// ErrBudgetExhausted is returned when growing a pool would exceed its shared memory budget.
+	ErrBudgetExhausted = errors.New("memory budget exhausted")
+)
+
+// budgetMember is implemented by pools that joined a budget.
+type budgetMember interface {
+	// reclaimForBudget tries to shrink the member to free budget for other members.
+	reclaimForBudget()
+}
+
+// Budget is a memory limit in bytes shared by several pools. Each member is charged for its
+// ring buffer capacity times its estimated object size, the bytes its capacity can hold rather than the
+// bytes its objects currently use, so a pool can only grow while the budget has room. When it
+// doesn't, the other members are asked to shrink first.
+type Budget struct {
+	mu sync.Mutex
+
+	// limit is the total amount of bytes available to the members.
+	limit int64
+
+	// used is the amount of bytes currently charged to the members.
+	used int64
+
+	// members holds the bytes charged to each member.
+	members map[budgetMember]int64
+}
+
+// NewBudget creates a budget of limitBytes shared by the pools that join it.
+func NewBudget(limitBytes int64) (*Budget, error) {
+	if limitBytes <= 0 {
+		return nil, fmt.Errorf("%w: budget limit must be greater than 0, got %d", ErrInvalidCapacity, limitBytes)
+	}
+
+	return &Budget{
+		limit:   limitBytes,
+		members: make(map[budgetMember]int64),
+	}, nil
+}
+
+// Limit returns the total amount of bytes of the budget.
+func (b *Budget) Limit() int64 {
+	return b.limit
+}
+
+// Used returns the amount of bytes currently charged to the members.
+func (b *Budget) Used() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used
+}
+
+// Available returns the amount of bytes that can still be charged.
+func (b *Budget) Available() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.limit - b.used
+}
+
+// charge sets the bytes charged to member. Increases that don't fit trigger a shrink
+// of the other members and fail with ErrBudgetExhausted if there's still not enough room.
+// Decreases always succeed, use lower for them.
+func (b *Budget) charge(member budgetMember, bytes int64) error {
+	if b.tryCharge(member, bytes) {
+		return nil
+	}
+
+	b.reclaim(member)
+
+	if b.tryCharge(member, bytes) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %d bytes requested, %d of %d available", ErrBudgetExhausted, bytes, b.Available(), b.limit)
+}
+
+// tryCharge sets the bytes charged to member if they fit in the budget.
+func (b *Budget) tryCharge(member budgetMember, bytes int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	current := b.members[member]
+	if bytes > current && b.used-current+bytes > b.limit {
+		return false
+	}
+
+	b.used += bytes - current
+	b.members[member] = bytes
+	return true
+}
+
+// lower reduces the bytes charged to member. Charges above the current one are ignored,
+// so unlike charge it can't fail.
+func (b *Budget) lower(member budgetMember, bytes int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	current, ok := b.members[member]
+	if !ok || bytes >= current {
+		return
+	}
+
+	b.used -= current - bytes
+	b.members[member] = bytes
+}
+
+// reclaim asks every member other than requester to shrink.
+// It's called without holding the budget lock, since shrinking members update their charge.
+func (b *Budget) reclaim(requester budgetMember) {
+	b.mu.Lock()
+	others := make([]budgetMember, 0, len(b.members))
+	for member := range b.members {
+		if member != requester {
+			others = append(others, member)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, member := range others {
+		member.reclaimForBudget()
+	}
+}
+
+// leave removes member from the budget and releases its charge.
+func (b *Budget) leave(member budgetMember) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.used -= b.members[member]
+	delete(b.members, member)
+}
+
+// JoinBudget makes the pool share the given memory budget with its other members.
+// sizeOf estimates the size in bytes of an object. It's called on the objects stored in the pool
+// when it joins and on every object created afterwards, and the mean of those estimates is charged
+// for every slot of the ring buffer capacity. A pool with no stored object is charged from its
+// first allocations on. The pool leaves the budget when closed.
+//
+// Returns ErrBudgetExhausted if the current capacity doesn't fit in the budget.
+func (p *Pool[T]) JoinBudget(budget *Budget, sizeOf func(T) int) error {
+	if budget == nil {
+		return errNilBudget
+	}
+
+	if sizeOf == nil {
+		return errNilSizeOf
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.budget != nil {
+		return errAlreadyInBudget
+	}
+
+	total, samples := p.sampleStoredSizes(sizeOf)
+	if err := budget.charge(p, int64(p.stats.currentCapacity)*meanSize(total, samples)); err != nil {
+		return err
+	}
+
+	p.sizeTotal.Store(total)
+	p.sizeSamples.Store(samples)
+	p.sizeOf.Store(&sizeOf)
+	p.budget = budget
+	return nil
+}
+
+// joinObjectBudget makes the pool join a budget where every object counts as one unit, so its
+// charge is its capacity. It's joined before any object is created, by newPool.
+func (p *Pool[T]) joinObjectBudget(budget *Budget) error {
+	unitSize := func(T) int {
+		return 1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := budget.charge(p, int64(p.stats.currentCapacity)); err != nil {
+		return err
+	}
+
+	// A single sample of one unit, which every object created afterwards keeps as the mean.
+	p.sizeTotal.Store(1)
+	p.sizeSamples.Store(1)
+	p.sizeOf.Store(&unitSize)
+	p.budget = budget
+	return nil
+}
+
+// sampleStoredSizes sums the estimated sizes of the objects stored in L1 and the ring buffer.
+// The L1 objects are taken out and put back, which can't block since Put needs the lock to
+// add objects. Must be called with p.mu held.
+func (p *Pool[T]) sampleStoredSizes(sizeOf func(T) int) (total, samples int64) {
+	part1, part2, err := p.pool.PeekNView(p.pool.Length(false))
+	if err == nil {
+		for _, part := range [][]T{part1, part2} {
+			for _, obj := range part {
+				total += int64(sizeOf(obj))
+				samples++
+			}
+		}
+	}
+
+	ch := *p.cacheL1
+	for range len(ch) {
+		select {
+		case obj := <-ch:
+			total += int64(sizeOf(obj))
+			samples++
+			ch <- obj
+		default:
+		}
+	}
+
+	return total, samples
+}
+
+// sampleSize adds the estimated size of a newly created object to the mean charged to the budget.
+// It's a no-op if the pool hasn't joined a budget.
+func (p *Pool[T]) sampleSize(obj T) {
+	sizeOf := p.sizeOf.Load()
+	if sizeOf == nil {
+		return
+	}
+
+	p.sizeTotal.Add(int64((*sizeOf)(obj)))
+	p.sizeSamples.Add(1)
+}
+
+// slotSize returns the size in bytes charged to the budget for every slot of the ring buffer capacity.
+func (p *Pool[T]) slotSize() int64 {
+	return meanSize(p.sizeTotal.Load(), p.sizeSamples.Load())
+}
+
+// meanSize returns the mean of samples object sizes summing to total, zero without samples.
+func meanSize(total, samples int64) int64 {
+	if samples == 0 {
+		return 0
+	}
+
+	return total / samples
+}
+
+// chargeBudget charges the pool's budget for the given ring buffer capacity.
+// It's a no-op if the pool hasn't joined a budget. Must be called with p.mu held.
+func (p *Pool[T]) chargeBudget(capacity int) error {
+	if p.budget == nil {
+		return nil
+	}
+
+	return p.budget.charge(p, int64(capacity)*p.slotSize())
+}
+
+// lowerBudget reduces the pool's charge to the given ring buffer capacity after a shrink or
+// a failed growth. It's a no-op if the pool hasn't joined a budget. Must be called with p.mu held.
+func (p *Pool[T]) lowerBudget(capacity int) {
+	if p.budget == nil {
+		return
+	}
+
+	p.budget.lower(p, int64(capacity)*p.slotSize())
+}
+
+// leaveBudget releases the pool's charge when it's closed.
+func (p *Pool[T]) leaveBudget() {
+	p.mu.Lock()
+	budget := p.budget
+	p.budget = nil
+	p.mu.Unlock()
+
+	if budget != nil {
+		budget.leave(p)
+	}
+}
+
+// reclaimForBudget runs a shrink cycle on behalf of another budget member, subject to the same
+// cooldown and maxConsecutiveShrinks limit as the background shrink. It's skipped when the pool
+// lock is busy, so pools growing at the same time can't deadlock on each other.
+func (p *Pool[T]) reclaimForBudget() {
+	if !p.mu.TryLock() {
+		return
+	}
+	defer p.mu.Unlock()
+
+	if p.ctx.Err() != nil {
+		return
+	}
+
+	now := p.config.clock.Now()
+	if !p.shrinkAllowed(now) {
+		return
+	}
+
+	p.shrinkExecution(now)
+}
@@ -0,0 +1,56 @@
+package pool
+
+import "time"
+
+// recentCapacityEvents is the number of capacity changes a pool remembers.
+const recentCapacityEvents = 32
+
+// CapacityEventKind tells what changed the ring buffer capacity.
+type CapacityEventKind string
+
+const (
+	// CapacityGrowth is a growth triggered by demand.
+	CapacityGrowth CapacityEventKind = "growth"
+
+	// CapacityShrink is a shrink cycle, from the shrink ticker or ShrinkNow.
+	CapacityShrink CapacityEventKind = "shrink"
+
+	// CapacityResize is a manual resize through ResizeTo or Prewarm.
+	CapacityResize CapacityEventKind = "resize"
+)
+
+// CapacityEvent is a change of the ring buffer capacity.
+type CapacityEvent struct {
+	Time time.Time
+	Kind CapacityEventKind
+	From int
+	To   int
+}
+
+// recordCapacityEvent remembers a capacity change, dropping the oldest once
+// recentCapacityEvents are held. Must be called with p.mu held.
+func (p *Pool[T]) recordCapacityEvent(kind CapacityEventKind, from, to int) {
+	event := CapacityEvent{
+		Time: p.config.clock.Now(),
+		Kind: kind,
+		From: from,
+		To:   to,
+	}
+
+	events := p.stats.recentEvents
+	if len(events) == recentCapacityEvents {
+		copy(events, events[1:])
+		events = events[:len(events)-1]
+	}
+	p.stats.recentEvents = append(events, event)
+}
+
+// RecentCapacityEvents returns the last capacity changes of the ring buffer, from oldest to newest.
+func (p *Pool[T]) RecentCapacityEvents() []CapacityEvent {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	events := make([]CapacityEvent, len(p.stats.recentEvents))
+	copy(events, p.stats.recentEvents)
+	return events
+}
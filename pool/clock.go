@@ -0,0 +1,68 @@
+package pool
+
+import "time"
+
+// Clock is the source of time for the pool's background work: the shrink and background fill
+// tickers, the shrink cooldown, the allocation failure backoff and the wait queue timeout. It defaults to the system
+// clock and can be replaced with SetClock, e.g. by a fake clock to test shrink behavior
+// without sleeping.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTicker returns a ticker that ticks every d.
+	NewTicker(d time.Duration) Ticker
+
+	// NewTimer returns a ticker that ticks once, after d.
+	NewTimer(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks at regular intervals, as time.Ticker.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+func (systemClock) NewTimer(d time.Duration) Ticker {
+	return systemTimer{time.NewTimer(d)}
+}
+
+// systemTicker adapts time.Ticker to the Ticker interface.
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t systemTicker) Stop() {
+	t.ticker.Stop()
+}
+
+// systemTimer adapts time.Timer to the Ticker interface.
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t systemTimer) Stop() {
+	t.timer.Stop()
+}
@@ -159,6 +159,10 @@ func (b *poolConfigBuilder[T]) validateFastPathConfig() error {
 func (b *poolConfigBuilder[T]) validateAllocationStrategy() error {
 	as := b.config.allocationStrategy
 
+	if as.Mode < AllocationPercent || as.Mode > AllocationEager {
+		return fmt.Errorf("allocationStrategy.Mode must be AllocationPercent, AllocationLazy or AllocationEager, got %d", as.Mode)
+	}
+
 	if as.AllocPercent <= 0 || as.AllocPercent > 100 {
 		return fmt.Errorf("allocationStrategy.AllocPercent must be between 0 and 100, got %d", as.AllocPercent)
 	}
@@ -167,5 +171,183 @@ func (b *poolConfigBuilder[T]) validateAllocationStrategy() error {
 		return fmt.Errorf("allocationStrategy.AllocAmount must be greater than 0, got %d", as.AllocAmount)
 	}
 
+	if as.FailureThreshold < 0 {
+		return fmt.Errorf("allocationStrategy.FailureThreshold must be >= 0, got %d", as.FailureThreshold)
+	}
+
+	if as.FailureThreshold > 0 && as.FailureBackoff <= 0 {
+		return fmt.Errorf("allocationStrategy.FailureBackoff must be greater than 0 when FailureThreshold is set, got %v", as.FailureBackoff)
+	}
+
+	return nil
+}
+
+// validateBackgroundFillConfig validates the background filler parameters when it's enabled:
+// - lowWatermark must be positive
+// - lowWatermark must be less than or equal to hardLimit
+// - checkInterval must be positive
+// Returns an error if any validation fails.
+func (b *poolConfigBuilder[T]) validateBackgroundFillConfig() error {
+	bf := b.config.backgroundFill
+	if !bf.enabled {
+		return nil
+	}
+
+	if bf.lowWatermark <= 0 {
+		return fmt.Errorf("backgroundFill.lowWatermark must be greater than 0, got %d", bf.lowWatermark)
+	}
+
+	if bf.lowWatermark > b.config.hardLimit {
+		return fmt.Errorf("backgroundFill.lowWatermark (%d) must be <= hardLimit (%d)", bf.lowWatermark, b.config.hardLimit)
+	}
+
+	if bf.checkInterval <= 0 {
+		return fmt.Errorf("backgroundFill.checkInterval must be greater than 0, got %v", bf.checkInterval)
+	}
+
+	return nil
+}
+
+// validateWaitQueueConfig validates the wait queue parameters:
+// - maxWaiters must be non-negative
+// Returns an error if any validation fails.
+func (b *poolConfigBuilder[T]) validateWaitQueueConfig() error {
+	wq := b.config.waitQueue
+	if wq.maxWaiters < 0 {
+		return fmt.Errorf("waitQueue.maxWaiters must be >= 0, got %d", wq.maxWaiters)
+	}
+
+	return nil
+}
+
+// validateAdaptiveFastPathConfig validates the adaptive fast path parameters when enabled:
+// - window must be positive
+// - minSize must be positive and maxSize must be >= minSize
+// - the fast path initial size must be within minSize and maxSize
+// - shrinkMissRate must be lower than growMissRate, and both between 0 and 1
+// Returns an error if any validation fails.
+func (b *poolConfigBuilder[T]) validateAdaptiveFastPathConfig() error {
+	afp := b.config.adaptiveFastPath
+	if !afp.enabled {
+		return nil
+	}
+
+	if afp.window <= 0 {
+		return fmt.Errorf("adaptiveFastPath.window must be greater than 0, got %v", afp.window)
+	}
+
+	if afp.minSize <= 0 {
+		return fmt.Errorf("adaptiveFastPath.minSize must be greater than 0, got %d", afp.minSize)
+	}
+
+	if afp.maxSize < afp.minSize {
+		return fmt.Errorf("adaptiveFastPath.maxSize (%d) must be >= minSize (%d)", afp.maxSize, afp.minSize)
+	}
+
+	initialSize := b.config.fastPath.initialSize
+	if initialSize < afp.minSize || initialSize > afp.maxSize {
+		return fmt.Errorf("fastPath.initialSize (%d) must be between adaptiveFastPath.minSize (%d) and maxSize (%d)", initialSize, afp.minSize, afp.maxSize)
+	}
+
+	if afp.growMissRate <= 0 || afp.growMissRate > 1 {
+		return fmt.Errorf("adaptiveFastPath.growMissRate must be between 0 and 1, got %f", afp.growMissRate)
+	}
+
+	if afp.shrinkMissRate < 0 || afp.shrinkMissRate >= afp.growMissRate {
+		return fmt.Errorf("adaptiveFastPath.shrinkMissRate (%f) must be >= 0 and < growMissRate (%f)", afp.shrinkMissRate, afp.growMissRate)
+	}
+
+	return nil
+}
+
+// validateRetryPolicy validates the retry policy of the slow paths:
+// - Mode must be one of RetryNone, RetryFixed or RetryExponential
+// - MaxRetries, MaxDelay and MaxElapsed must be non-negative
+// - InitialDelay must be positive for RetryExponential, non-negative otherwise
+// - Jitter must be between 0 and 1
+// Returns an error if any validation fails.
+func (b *poolConfigBuilder[T]) validateRetryPolicy() error {
+	rp := b.config.retryPolicy
+
+	if rp.Mode < RetryNone || rp.Mode > RetryExponential {
+		return fmt.Errorf("retryPolicy.Mode is not a valid retry mode, got %d", rp.Mode)
+	}
+
+	if rp.MaxRetries < 0 {
+		return fmt.Errorf("retryPolicy.MaxRetries must be >= 0, got %d", rp.MaxRetries)
+	}
+
+	if rp.InitialDelay < 0 {
+		return fmt.Errorf("retryPolicy.InitialDelay must be >= 0, got %v", rp.InitialDelay)
+	}
+
+	if rp.Mode == RetryExponential && rp.InitialDelay == 0 {
+		return fmt.Errorf("retryPolicy.InitialDelay must be greater than 0 for exponential retries")
+	}
+
+	if rp.MaxDelay < 0 {
+		return fmt.Errorf("retryPolicy.MaxDelay must be >= 0, got %v", rp.MaxDelay)
+	}
+
+	if rp.Jitter < 0 || rp.Jitter > 1 {
+		return fmt.Errorf("retryPolicy.Jitter must be between 0 and 1, got %v", rp.Jitter)
+	}
+
+	if rp.MaxElapsed < 0 {
+		return fmt.Errorf("retryPolicy.MaxElapsed must be >= 0, got %v", rp.MaxElapsed)
+	}
+
+	return nil
+}
+
+// validateObjectTrackingConfig validates the object tracking parameters:
+// - maxReuses must be non-negative
+// Returns an error if any validation fails.
+func (b *poolConfigBuilder[T]) validateObjectTrackingConfig() error {
+	ot := b.config.objectTracking
+	if ot.maxReuses < 0 {
+		return fmt.Errorf("objectTracking.maxReuses must be >= 0, got %d", ot.maxReuses)
+	}
+
+	return nil
+}
+
+// validateHoldTimeoutConfig validates the hold timeout parameters when enabled:
+// - maxHoldTime and checkInterval must be positive
+// Returns an error if any validation fails.
+func (b *poolConfigBuilder[T]) validateHoldTimeoutConfig() error {
+	ht := b.config.holdTimeout
+	if !ht.enabled {
+		return nil
+	}
+
+	if ht.maxHoldTime <= 0 {
+		return fmt.Errorf("holdTimeout.maxHoldTime must be greater than 0, got %v", ht.maxHoldTime)
+	}
+
+	if ht.checkInterval <= 0 {
+		return fmt.Errorf("holdTimeout.checkInterval must be greater than 0, got %v", ht.checkInterval)
+	}
+
+	return nil
+}
+
+// validateInitialState validates the initial state when set:
+// - capacities and counters must be non-negative
+// Returns an error if any validation fails.
+func (b *poolConfigBuilder[T]) validateInitialState() error {
+	state := b.config.initialState
+	if state == nil {
+		return nil
+	}
+
+	if state.Capacity < 0 || state.L1Capacity < 0 {
+		return fmt.Errorf("initialState capacities must be >= 0, got %d and %d for L1", state.Capacity, state.L1Capacity)
+	}
+
+	if state.TotalGrowthEvents < 0 || state.TotalShrinkEvents < 0 || state.LastL1ResizeAtGrowthNum < 0 || state.LastResizeAtShrinkNum < 0 {
+		return fmt.Errorf("initialState counters must be >= 0, got %+v", *state)
+	}
+
 	return nil
 }
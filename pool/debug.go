@@ -0,0 +1,365 @@
+package pool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	errEmptyPoolName     = errors.New("pool name must not be empty")
+	errNilDebugSource    = errors.New("pool must not be nil")
+	errDuplicatePoolName = errors.New("a pool is already registered under this name")
+)
+
+// DebugSource is implemented by *Pool and *KeyedPool, so they can be registered for inspection.
+// Other types implement it to be listed along with them. The Name of the returned info is set
+// by the registry.
+type DebugSource interface {
+	DebugInfo() PoolDebugInfo
+}
+
+// ConfigSetting is a configuration value as reported by the PoolConfig getters.
+type ConfigSetting struct {
+	Name  string
+	Value string
+}
+
+// PoolDebugInfo is the state of a registered pool as served by DebugHandler.
+type PoolDebugInfo struct {
+	Name   string
+	Config []ConfigSetting
+	Stats  PoolStatsSnapshot
+
+	// RecentEvents are the last capacity changes, from oldest to newest.
+	RecentEvents []CapacityEvent
+
+	// OutstandingObjects is the number of objects currently held by callers.
+	OutstandingObjects uint64
+
+	// BlockedReaders is the number of callers blocked on the ring buffer, Waiters the
+	// number of callers in the wait queue.
+	BlockedReaders int
+	Waiters        int
+}
+
+// Registry holds named pools for DebugHandler.
+type Registry struct {
+	mu    sync.RWMutex
+	pools map[string]DebugSource
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{pools: make(map[string]DebugSource)}
+}
+
+// Register adds a pool under name. Names must be unique within the registry.
+func (r *Registry) Register(name string, p DebugSource) error {
+	if name == "" {
+		return errEmptyPoolName
+	}
+
+	if p == nil {
+		return errNilDebugSource
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.pools[name]; ok {
+		return fmt.Errorf("%w: %q", errDuplicatePoolName, name)
+	}
+
+	r.pools[name] = p
+	return nil
+}
+
+// Unregister removes the pool registered under name, if any.
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pools, name)
+}
+
+// Inspect returns the state of every registered pool, sorted by name.
+func (r *Registry) Inspect() []PoolDebugInfo {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.pools))
+	sources := make(map[string]DebugSource, len(r.pools))
+	for name, p := range r.pools {
+		names = append(names, name)
+		sources[name] = p
+	}
+	r.mu.RUnlock()
+
+	slices.Sort(names)
+
+	infos := make([]PoolDebugInfo, 0, len(names))
+	for _, name := range names {
+		info := sources[name].DebugInfo()
+		info.Name = name
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// DebugInfo collects the state of the pool. Each part takes the read lock on its own,
+// so they may be a few operations apart under load.
+func (p *Pool[T]) DebugInfo() PoolDebugInfo {
+	stats := p.GetPoolStatsSnapshot()
+
+	return PoolDebugInfo{
+		Config:             p.config.settings(),
+		Stats:              *stats,
+		RecentEvents:       p.RecentCapacityEvents(),
+		OutstandingObjects: stats.ObjectsInUse,
+		BlockedReaders:     p.GetBlockedReaders(),
+		Waiters:            p.Waiters(),
+	}
+}
+
+// DebugInfo collects the state of all keys combined: the merged statistics, the shared
+// configuration and the most recent capacity events across keys. Pools of keys created or
+// evicted meanwhile may be missing.
+func (kp *KeyedPool[K, T]) DebugInfo() PoolDebugInfo {
+	kp.mu.Lock()
+	pools := make([]*Pool[T], 0, len(kp.pools))
+	for _, entry := range kp.pools {
+		pools = append(pools, entry.pool)
+	}
+	kp.mu.Unlock()
+
+	snapshots := make([]*PoolStatsSnapshot, 0, len(pools))
+	info := PoolDebugInfo{
+		Config: append(kp.config.settings(),
+			ConfigSetting{Name: "keyed.globalHardLimit", Value: fmt.Sprint(kp.globalHardLimit)},
+			ConfigSetting{Name: "keyed.idleTimeout", Value: fmt.Sprint(kp.idleTimeout)},
+			ConfigSetting{Name: "keyed.keys", Value: fmt.Sprint(len(pools))},
+		),
+	}
+
+	for _, p := range pools {
+		snapshots = append(snapshots, p.GetPoolStatsSnapshot())
+		info.RecentEvents = append(info.RecentEvents, p.RecentCapacityEvents()...)
+		info.BlockedReaders += p.GetBlockedReaders()
+		info.Waiters += p.Waiters()
+	}
+
+	info.Stats = *mergeSnapshots(snapshots)
+	info.OutstandingObjects = info.Stats.ObjectsInUse
+
+	slices.SortStableFunc(info.RecentEvents, func(a, b CapacityEvent) int {
+		return a.Time.Compare(b.Time)
+	})
+	if len(info.RecentEvents) > recentCapacityEvents {
+		info.RecentEvents = info.RecentEvents[len(info.RecentEvents)-recentCapacityEvents:]
+	}
+
+	return info
+}
+
+// settings lists the configuration through its getters, named as in the builder setters.
+func (c *PoolConfig[T]) settings() []ConfigSetting {
+	var settings []ConfigSetting
+	add := func(name string, value any) {
+		settings = append(settings, ConfigSetting{Name: name, Value: fmt.Sprint(value)})
+	}
+
+	add("initialCapacity", c.GetInitialCapacity())
+	add("hardLimit", c.GetHardLimit())
+
+	growth := c.GetGrowth()
+	add("growth.thresholdFactor", growth.GetThresholdFactor())
+	add("growth.bigGrowthFactor", growth.GetBigGrowthFactor())
+	add("growth.controlledGrowthFactor", growth.GetControlledGrowthFactor())
+
+	addShrink := func(prefix string, shrink *shrinkParameters) {
+		add(prefix+".aggressivenessLevel", shrink.GetAggressivenessLevel())
+		add(prefix+".checkInterval", shrink.GetCheckInterval())
+		add(prefix+".shrinkCooldown", shrink.GetShrinkCooldown())
+		add(prefix+".minUtilizationBeforeShrink", shrink.GetMinUtilizationBeforeShrink())
+		add(prefix+".stableUnderutilizationRounds", shrink.GetStableUnderutilizationRounds())
+		add(prefix+".shrinkPercent", shrink.GetShrinkPercent())
+		add(prefix+".maxConsecutiveShrinks", shrink.GetMaxConsecutiveShrinks())
+		add(prefix+".minCapacity", shrink.GetMinCapacity())
+	}
+	addShrink("shrink", c.GetShrink())
+
+	fastPath := c.GetFastPath()
+	add("fastPath.initialSize", fastPath.GetInitialSize())
+	add("fastPath.fillAggressiveness", fastPath.GetFillAggressiveness())
+	add("fastPath.refillPercent", fastPath.GetRefillPercent())
+	add("fastPath.enableChannelGrowth", fastPath.IsEnableChannelGrowth())
+	add("fastPath.growthEventsTrigger", fastPath.GetGrowthEventsTrigger())
+	add("fastPath.shrinkEventsTrigger", fastPath.GetShrinkEventsTrigger())
+	add("fastPath.preReadBlockHookAttempts", fastPath.GetPreReadBlockHookAttempts())
+	add("fastPath.growth.bigGrowthFactor", fastPath.GetGrowth().GetBigGrowthFactor())
+	add("fastPath.growth.controlledGrowthFactor", fastPath.GetGrowth().GetControlledGrowthFactor())
+	addShrink("fastPath.shrink", fastPath.GetShrink())
+
+	adaptive := c.GetAdaptiveFastPath()
+	add("adaptiveFastPath.enabled", adaptive.IsEnabled())
+	if adaptive.IsEnabled() {
+		add("adaptiveFastPath.window", adaptive.GetWindow())
+		add("adaptiveFastPath.minSize", adaptive.GetMinSize())
+		add("adaptiveFastPath.maxSize", adaptive.GetMaxSize())
+		add("adaptiveFastPath.growMissRate", adaptive.GetGrowMissRate())
+		add("adaptiveFastPath.shrinkMissRate", adaptive.GetShrinkMissRate())
+	}
+
+	ringBuffer := c.GetRingBufferConfig()
+	add("ringBuffer.block", ringBuffer.Block)
+	add("ringBuffer.readTimeout", ringBuffer.RTimeout)
+	add("ringBuffer.writeTimeout", ringBuffer.WTimeout)
+
+	allocation := c.allocationStrategy
+	add("allocationStrategy.mode", allocation.Mode)
+	add("allocationStrategy.allocPercent", allocation.AllocPercent)
+	add("allocationStrategy.allocAmount", allocation.AllocAmount)
+	add("allocationStrategy.batch", c.GetAllocBatch() != nil)
+
+	backgroundFill := c.GetBackgroundFill()
+	add("backgroundFill.enabled", backgroundFill.IsEnabled())
+	if backgroundFill.IsEnabled() {
+		add("backgroundFill.lowWatermark", backgroundFill.GetLowWatermark())
+		add("backgroundFill.checkInterval", backgroundFill.GetCheckInterval())
+	}
+
+	waitQueue := c.GetWaitQueue()
+	add("waitQueue.enabled", waitQueue.IsEnabled())
+	if waitQueue.IsEnabled() {
+		add("waitQueue.maxWaiters", waitQueue.GetMaxWaiters())
+	}
+
+	tracking := c.GetObjectTracking()
+	add("objectTracking.enabled", tracking.IsEnabled())
+	if tracking.IsEnabled() {
+		add("objectTracking.maxReuses", tracking.GetMaxReuses())
+		add("objectTracking.captureCaller", tracking.IsCaptureCaller())
+	}
+
+	holdTimeout := c.GetHoldTimeout()
+	add("holdTimeout.enabled", holdTimeout.IsEnabled())
+	if holdTimeout.IsEnabled() {
+		add("holdTimeout.maxHoldTime", holdTimeout.GetMaxHoldTime())
+		add("holdTimeout.checkInterval", holdTimeout.GetCheckInterval())
+		add("holdTimeout.reclaim", holdTimeout.IsReclaim())
+	}
+
+	if state := c.GetInitialState(); state != nil {
+		add("initialState.capacity", state.Capacity)
+		add("initialState.l1Capacity", state.L1Capacity)
+	}
+
+	add("handle.debug", c.GetHandlePoison() != nil)
+
+	retry := c.GetRetryPolicy()
+	add("retryPolicy.mode", retry.Mode)
+	add("retryPolicy.maxRetries", retry.MaxRetries)
+	add("retryPolicy.initialDelay", retry.InitialDelay)
+	add("retryPolicy.maxDelay", retry.MaxDelay)
+	add("retryPolicy.jitter", retry.Jitter)
+	add("retryPolicy.maxElapsed", retry.MaxElapsed)
+
+	return settings
+}
+
+// DebugHandler serves the state of the pools in registry, usually mounted at /debug/poolx.
+// It renders an HTML page by default and JSON when the request has format=json or accepts
+// application/json. The name parameter restricts the output to a single pool.
+func DebugHandler(registry *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		infos := registry.Inspect()
+
+		if name := r.URL.Query().Get("name"); name != "" {
+			infos = slices.DeleteFunc(infos, func(info PoolDebugInfo) bool {
+				return info.Name != name
+			})
+
+			if len(infos) == 0 {
+				http.Error(w, fmt.Sprintf("no pool registered as %q", name), http.StatusNotFound)
+				return
+			}
+		}
+
+		if wantsJSON(r) {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			_ = encoder.Encode(infos)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := debugPage.Execute(w, infos); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func wantsJSON(r *http.Request) bool {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format == "json"
+	}
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+var debugPage = template.Must(template.New("poolx").Funcs(template.FuncMap{
+	"percent": func(ratio float64) float64 {
+		return ratio * 100
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>/debug/poolx</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+td, th { border: 1px solid #ccc; padding: 2px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>/debug/poolx</h1>
+<p>{{len .}} registered pools. <a href="?format=json">JSON</a></p>
+{{range .}}
+<h2 id="{{.Name}}">{{.Name}}</h2>
+<table>
+<tr><th>Outstanding objects</th><td>{{.OutstandingObjects}}</td></tr>
+<tr><th>Blocked readers</th><td>{{.BlockedReaders}}</td></tr>
+<tr><th>Waiters</th><td>{{.Waiters}}</td></tr>
+<tr><th>Capacity</th><td>{{.Stats.CurrentCapacity}} of {{.Stats.HardLimit}}</td></tr>
+<tr><th>L1 capacity</th><td>{{.Stats.CurrentL1Capacity}}</td></tr>
+<tr><th>Available objects</th><td>{{.Stats.AvailableObjects}}</td></tr>
+<tr><th>Objects created / destroyed</th><td>{{.Stats.ObjectsCreated}} / {{.Stats.ObjectsDestroyed}}</td></tr>
+<tr><th>Total gets</th><td>{{.Stats.TotalGets}}</td></tr>
+<tr><th>Growth / shrink events</th><td>{{.Stats.TotalGrowthEvents}} / {{.Stats.TotalShrinkEvents}}</td></tr>
+<tr><th>Fast return hit / miss</th><td>{{.Stats.FastReturnHit}} / {{.Stats.FastReturnMiss}}</td></tr>
+<tr><th>L2 spill rate</th><td>{{printf "%.4f" .Stats.L2SpillRate}}</td></tr>
+<tr><th>Utilization</th><td>{{printf "%.2f" (percent .Stats.Utilization)}}%</td></tr>
+</table>
+<h3>Recent capacity events</h3>
+{{if .RecentEvents}}
+<table>
+<tr><th>Time</th><th>Kind</th><th>From</th><th>To</th></tr>
+{{range .RecentEvents}}<tr><td>{{.Time.Format "2006-01-02 15:04:05.000"}}</td><td>{{.Kind}}</td><td>{{.From}}</td><td>{{.To}}</td></tr>
+{{end}}
+</table>
+{{else}}
+<p>None.</p>
+{{end}}
+<h3>Configuration</h3>
+<table>
+{{range .Config}}<tr><th>{{.Name}}</th><td>{{.Value}}</td></tr>
+{{end}}
+</table>
+{{end}}
+</body>
+</html>
+`))
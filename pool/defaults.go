@@ -7,31 +7,38 @@ import (
 type AggressivenessLevel int
 
 const (
-	defaultAggressiveness             AggressivenessLevel = AggressivenessBalanced
-	AggressivenessDisabled            AggressivenessLevel = 0
-	AggressivenessConservative        AggressivenessLevel = 1
-	AggressivenessBalanced            AggressivenessLevel = 2
-	AggressivenessAggressive          AggressivenessLevel = 3
-	AggressivenessVeryAggressive      AggressivenessLevel = 4
-	AggressivenessExtreme             AggressivenessLevel = 5
-	defaultExponentialThresholdFactor                     = 1.0 // 100%
-	defaultGrowthFactor                                   = 0.5 // 50%
-	defaultFixedGrowthFactor                              = 0.1 // 10%
-	defaultfillAggressiveness                             = 80
-	fillAggressivenessExtreme                             = 100
-	defaultRefillPercent                                  = 20
-	defaultMinCapacity                                    = 32
-	defaultPoolCapacity                                   = 64
-	defaultL1MinCapacity                                  = defaultPoolCapacity // L1 doesn't go below its initial capacity
-	defaultHardLimit                                      = 10_000
-	defaultGrowthEventsTrigger                            = 3
-	defaultShrinkEventsTrigger                            = 3
-	defaultPreReadBlockHookAttempts                       = 3
-	defaultEnableChannelGrowth                            = true
-	defaultEnableStats                                    = false
-	Block                                                 = false
-	RTimeout                                              = 0
-	WTimeout                                              = 0
+	defaultAggressiveness                 AggressivenessLevel = AggressivenessBalanced
+	AggressivenessDisabled                AggressivenessLevel = 0
+	AggressivenessConservative            AggressivenessLevel = 1
+	AggressivenessBalanced                AggressivenessLevel = 2
+	AggressivenessAggressive              AggressivenessLevel = 3
+	AggressivenessVeryAggressive          AggressivenessLevel = 4
+	AggressivenessExtreme                 AggressivenessLevel = 5
+	defaultExponentialThresholdFactor                         = 1.0 // 100%
+	defaultGrowthFactor                                       = 0.5 // 50%
+	defaultFixedGrowthFactor                                  = 0.1 // 10%
+	defaultfillAggressiveness                                 = 80
+	fillAggressivenessExtreme                                 = 100
+	defaultRefillPercent                                      = 20
+	defaultMinCapacity                                        = 32
+	defaultPoolCapacity                                       = 64
+	defaultL1MinCapacity                                      = defaultPoolCapacity // L1 doesn't go below its initial capacity
+	defaultHardLimit                                          = 10_000
+	defaultGrowthEventsTrigger                                = 3
+	defaultShrinkEventsTrigger                                = 3
+	defaultPreReadBlockHookAttempts                           = 3
+	defaultEnableChannelGrowth                                = true
+	defaultEnableStats                                        = false
+	defaultBackgroundFillInterval                             = 100 * time.Millisecond
+	defaultAdaptiveFastPathWindow                             = time.Second
+	defaultAdaptiveFastPathMinSize                            = 8
+	defaultAdaptiveFastPathMaxSize                            = 4096
+	defaultAdaptiveFastPathGrowMissRate                       = 0.10
+	defaultAdaptiveFastPathShrinkMissRate                     = 0.01
+	defaultHoldTimeoutCheckInterval                           = time.Second
+	Block                                                     = false
+	RTimeout                                                  = 0
+	WTimeout                                                  = 0
 )
 
 var defaultShrinkMap = map[AggressivenessLevel]*shrinkDefaults{
@@ -111,3 +118,41 @@ var defaultAllocationStrategy = &AllocationStrategy{
 	AllocPercent: 30,
 	AllocAmount:  30,
 }
+
+var defaultBackgroundFill = &backgroundFillParameters{
+	enabled:       false,
+	checkInterval: defaultBackgroundFillInterval,
+}
+
+var defaultClock Clock = systemClock{}
+
+var defaultRetryPolicy = &RetryPolicy{
+	Mode:         RetryFixed,
+	MaxRetries:   4,
+	InitialDelay: 10 * time.Millisecond,
+}
+
+var defaultWaitQueue = &waitQueueParameters{
+	enabled:    false,
+	maxWaiters: 0,
+}
+
+var defaultObjectTracking = &objectTrackingParameters{
+	enabled:       false,
+	maxReuses:     0,
+	captureCaller: false,
+}
+
+var defaultHoldTimeout = &holdTimeoutParameters{
+	enabled:       false,
+	checkInterval: defaultHoldTimeoutCheckInterval,
+}
+
+var defaultAdaptiveFastPath = &adaptiveFastPathParameters{
+	enabled:        false,
+	window:         defaultAdaptiveFastPathWindow,
+	minSize:        defaultAdaptiveFastPathMinSize,
+	maxSize:        defaultAdaptiveFastPathMaxSize,
+	growMissRate:   defaultAdaptiveFastPathGrowMissRate,
+	shrinkMissRate: defaultAdaptiveFastPathShrinkMissRate,
+}
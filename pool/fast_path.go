@@ -49,7 +49,7 @@ func (p *Pool[T]) drainOldChannel(oldCh, newCh chan T) error {
 // the configured trigger threshold. It implements an adaptive growth strategy that uses either
 // exponential or fixed growth based on the current capacity relative to a threshold.
 func (p *Pool[T]) tryL1ResizeIfTriggered() error {
-	if !p.config.fastPath.enableChannelGrowth {
+	if !p.config.fastPath.enableChannelGrowth || p.config.adaptiveFastPath.enabled {
 		return nil
 	}
 
@@ -82,9 +82,11 @@ func (p *Pool[T]) tryL1ResizeIfTriggered() error {
 // tryGetFromL1 attempts to retrieve an object from the L1 cache channel.
 // Returns the object and true if found, otherwise returns zero value and false.
 func (p *Pool[T]) tryGetFromL1(locked bool) (zero T, found bool) {
-	var chPtr *chan T = p.cacheL1
+	var chPtr *chan T
 
-	if !locked {
+	if locked {
+		chPtr = p.cacheL1
+	} else {
 		p.mu.RLock()
 		chPtr = p.cacheL1
 		p.mu.RUnlock()
@@ -95,7 +97,7 @@ func (p *Pool[T]) tryGetFromL1(locked bool) (zero T, found bool) {
 		if !ok {
 			return zero, false
 		}
-		p.stats.totalGets.Add(1)
+		p.stats.recordGet()
 
 		return obj, true
 	default:
@@ -106,6 +108,7 @@ func (p *Pool[T]) tryGetFromL1(locked bool) (zero T, found bool) {
 // tryFastPathPut attempts to quickly return an object to the L1 cache channel using a non-blocking
 // select operation. If successful, it updates hit statistics and returns true.
 // If the channel is full, it returns false to indicate a miss.
+// The read lock is held across the send so resizes can't close the channel concurrently.
 func (p *Pool[T]) tryFastPathPut(obj T) bool {
 	defer func() bool {
 		if r := recover(); r != nil {
@@ -116,10 +119,9 @@ func (p *Pool[T]) tryFastPathPut(obj T) bool {
 	}()
 
 	p.mu.RLock()
-	chPtr := p.cacheL1
-	p.mu.RUnlock()
+	defer p.mu.RUnlock()
 
-	ch := *chPtr
+	ch := *p.cacheL1
 
 	select {
 	case ch <- obj:
@@ -154,7 +156,8 @@ func (p *Pool[T]) calculateFillTarget(currentCap int) int {
 		return 0
 	}
 
-	targetFill := currentCap * p.config.fastPath.fillAggressiveness / 100
+	// At least one object, or pools too small for the fill aggressiveness would never fill L1.
+	targetFill := max(currentCap*p.config.fastPath.fillAggressiveness/100, 1)
 
 	chPtr := p.cacheL1
 	ch := *chPtr
@@ -184,7 +187,7 @@ func (p *Pool[T]) shouldShrinkFastPath() bool {
 func (p *Pool[T]) adjustFastPathShrinkTarget(currentCap int) int {
 	cfg := p.config.fastPath.shrink
 	newCap := currentCap * (100 - cfg.shrinkPercent) / 100
-	inUse := int(p.stats.totalGets.Load() - (p.stats.FastReturnHit.Load() + p.stats.FastReturnMiss.Load()))
+	inUse := p.stats.inUse()
 
 	if newCap < cfg.minCapacity {
 		return cfg.minCapacity
@@ -36,19 +36,20 @@ func (p *Pool[T]) needsToShrinkToHardLimit(newCapacity int) bool {
 
 // ShrinkExecution orchestrates the complete shrinking process for both the main pool and L1 cache.
 // It handles capacity calculations, validation, and performs the actual shrinking operations
-// while maintaining proper logging and statistics.
-func (p *Pool[T]) shrinkExecution() {
+// while maintaining proper logging and statistics. now is the time the shrink is recorded at,
+// the tick time for the background shrink so cooldowns are measured between ticks.
+func (p *Pool[T]) shrinkExecution(now time.Time) {
 	currentCap := p.stats.currentCapacity
 	newCapacity := int(currentCap) * (100 - p.config.shrink.shrinkPercent) / 100
 	if !p.shouldShrinkMainPool(currentCap, newCapacity) {
 		return
 	}
 
-	inUse := int(p.stats.totalGets.Load() - (p.stats.FastReturnHit.Load() + p.stats.FastReturnMiss.Load()))
+	inUse := p.stats.inUse()
 	newCapacity = p.adjustMainShrinkTarget(newCapacity, inUse)
-	p.performShrink(newCapacity, inUse)
+	p.performShrink(newCapacity, inUse, now)
 
-	if !p.config.fastPath.enableChannelGrowth || !p.shouldShrinkFastPath() {
+	if !p.config.fastPath.enableChannelGrowth || p.config.adaptiveFastPath.enabled || !p.shouldShrinkFastPath() {
 		return
 	}
 
@@ -61,13 +62,14 @@ func (p *Pool[T]) shrinkExecution() {
 // performShrink executes the actual shrinking of the main pool by creating a new ring buffer
 // with the target capacity and copying available objects from the old buffer.
 // It preserves in-use objects and updates pool statistics.
-func (p *Pool[T]) performShrink(newCapacity, inUse int) {
+func (p *Pool[T]) performShrink(newCapacity, inUse int, now time.Time) {
 	if !p.canShrink(newCapacity, inUse) {
 		return
 	}
 
 	newRingBuffer := p.createShrinkBuffer(newCapacity)
-	itemsToKeep := p.calculateItemsToKeep(newCapacity, inUse)
+	inL1 := p.trimL1ToFit(newCapacity, inUse)
+	itemsToKeep := p.calculateItemsToKeep(newCapacity, inUse+inL1)
 
 	totalItems := p.pool.Length(false)
 	destroyedCount := totalItems - itemsToKeep
@@ -79,7 +81,7 @@ func (p *Pool[T]) performShrink(newCapacity, inUse int) {
 		return
 	}
 
-	p.finalizeShrink(newRingBuffer, newCapacity)
+	p.finalizeShrink(newRingBuffer, newCapacity, now)
 }
 
 // canShrink checks if the pool can be shrunk based on the new capacity and in-use objects
@@ -101,6 +103,23 @@ func (p *Pool[T]) calculateItemsToKeep(newCapacity, inUse int) int {
 	return min(availableToKeep, p.pool.Length(false))
 }
 
+// trimL1ToFit drops the L1 objects that wouldn't fit in a ring buffer of the given capacity
+// next to the objects in use, so a Put missing the fast path always finds room.
+// Returns the number of objects left in L1. Must be called with p.mu held.
+func (p *Pool[T]) trimL1ToFit(newCapacity, inUse int) int {
+	ch := *p.cacheL1
+	for len(ch) > max(newCapacity-inUse, 0) {
+		select {
+		case obj := <-ch:
+			p.tracker.forget(obj)
+			p.stats.objectsDestroyed++
+		default:
+		}
+	}
+
+	return len(ch)
+}
+
 // migrateItems moves items from the old buffer to the new buffer
 func (p *Pool[T]) migrateItems(newRingBuffer *ringbuffer.RingBuffer[T], itemsToKeep int) error {
 	if itemsToKeep <= 0 {
@@ -133,12 +152,15 @@ func (p *Pool[T]) writeItemsToNewBuffer(newRingBuffer *ringbuffer.RingBuffer[T],
 }
 
 // finalizeShrink updates the pool with the new buffer and updates statistics
-func (p *Pool[T]) finalizeShrink(newRingBuffer *ringbuffer.RingBuffer[T], newCapacity int) {
+func (p *Pool[T]) finalizeShrink(newRingBuffer *ringbuffer.RingBuffer[T], newCapacity int, now time.Time) {
+	p.forgetRingBufferObjects()
 	p.pool.Close()
 	p.pool = newRingBuffer
+	p.recordCapacityEvent(CapacityShrink, p.stats.currentCapacity, newCapacity)
 	p.stats.currentCapacity = newCapacity
+	p.lowerBudget(newCapacity)
 	p.stats.totalShrinkEvents++
-	p.stats.lastShrinkTime = time.Now()
+	p.stats.lastShrinkTime = now
 	p.stats.consecutiveShrinks++
 }
 
@@ -249,7 +271,7 @@ func (p *Pool[T]) validateAndWriteItems(newRingBuffer *ringbuffer.RingBuffer[T],
 }
 
 func (p *Pool[T]) fillRemainingCapacity(newCapacity int) error {
-	allocAmount := newCapacity * p.config.allocationStrategy.AllocPercent / 100
+	allocAmount := p.preallocAmount(newCapacity)
 	spaceAvailable := newCapacity - (p.stats.objectsCreated - p.stats.objectsDestroyed)
 	toAdd := min(allocAmount, spaceAvailable)
 	if toAdd <= 0 {
@@ -265,29 +287,30 @@ func (p *Pool[T]) fillRemainingCapacity(newCapacity int) error {
 }
 
 // updatePoolCapacity handles the core capacity update logic, including hard limit checks
-// and the creation/population of the new buffer. It's the main entry point for
-// capacity changes in the pool.
+// and the creation of the new buffer holding the existing objects. It's the main entry point
+// for capacity changes in the pool. The new capacity is filled separately, see fillRemainingCapacity,
+// so a failed allocation doesn't leave a growth half applied.
 func (p *Pool[T]) updatePoolCapacity(newCapacity int) error {
 	if p.needsToShrinkToHardLimit(newCapacity) {
 		newCapacity = p.config.hardLimit
-		p.isGrowthBlocked.Store(true)
 	}
 
-	if newCapacity == p.config.hardLimit {
-		p.isGrowthBlocked.Store(true)
+	if err := p.chargeBudget(newCapacity); err != nil {
+		return err
 	}
 
 	newRingBuffer, err := p.createAndPopulateBuffer(newCapacity)
 	if err != nil {
+		p.lowerBudget(p.stats.currentCapacity)
 		return err
 	}
 
+	if newCapacity == p.config.hardLimit {
+		p.isGrowthBlocked.Store(true)
+	}
+
 	p.pool = newRingBuffer
 	p.stats.currentCapacity = newCapacity
 
-	if err := p.fillRemainingCapacity(newCapacity); err != nil {
-		return fmt.Errorf("failed to fill remaining capacity: %w", err)
-	}
-
 	return nil
 }
@@ -0,0 +1,91 @@
+package pool
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrHandleReleased is the panic value of Handle.Value after Release.
+var ErrHandleReleased = errors.New("handle used after release")
+
+// Handle wraps an object checked out with GetHandle. The object is returned to the pool
+// by Release, after which Value panics, so a use after Put surfaces as a panic instead of
+// a data race with the object's next holder.
+//
+// A Handle only guards accesses through Value, callers must not keep the object itself
+// past Release. The handle debug mode, see SetHandleDebugConfigs, catches those in tests.
+type Handle[T any] struct {
+	pool     *Pool[T]
+	obj      T
+	released atomic.Bool
+}
+
+// GetHandle retrieves an object from the pool like Get, wrapped in a Handle.
+func (p *Pool[T]) GetHandle() (*Handle[T], error) {
+	obj, err := p.get()
+	if err != nil {
+		return nil, err
+	}
+
+	p.tracker.checkout(obj, 1)
+	return &Handle[T]{pool: p, obj: obj}, nil
+}
+
+// Value returns the wrapped object. It panics with ErrHandleReleased after Release.
+func (h *Handle[T]) Value() T {
+	if h.released.Load() {
+		panic(ErrHandleReleased)
+	}
+	return h.obj
+}
+
+// Released reports whether Release was called.
+func (h *Handle[T]) Released() bool {
+	return h.released.Load()
+}
+
+// Release returns the object to the pool. Only the first call does, later calls
+// return nil, so it's safe to both defer Release and call it early.
+func (h *Handle[T]) Release() error {
+	if !h.released.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	if h.pool.config.handlePoison != nil {
+		h.pool.poison(h.obj)
+		return nil
+	}
+
+	return h.pool.Put(h.obj)
+}
+
+// poison passes a released object to the handle debug poison function and drops it
+// instead of storing it, so it's never handed out again and keeps its poisoned state.
+func (p *Pool[T]) poison(obj T) {
+	defer func() {
+		p.refillCond.Signal()
+		p.waiters.wakeHead()
+	}()
+
+	result := p.tracker.checkin(obj)
+	if result == checkinDiscard {
+		// Reclaimed after a hold timeout, it's no longer accounted for and only has to be
+		// cleaned, before poisoning so it keeps its poisoned state.
+		p.cleaner(obj)
+	}
+
+	p.config.handlePoison(obj)
+
+	if result == checkinDiscard {
+		return
+	}
+
+	p.tracker.forget(obj)
+	p.stats.objectsInUse.Add(-1)
+
+	p.mu.Lock()
+	p.stats.objectsDestroyed++
+	p.mu.Unlock()
+
+	p.stats.poisonedObjects.Add(1)
+}
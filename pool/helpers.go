@@ -45,8 +45,8 @@ func (p *Pool[T]) setPoolAndBuffer(obj T, fastPathRemaining int) (int, error) {
 // calculateUtilization calculates the current utilization percentage of the pool.
 // Returns 0 if there are no objects in the pool or if the L1 cache is nil.
 func (p *Pool[T]) calculateUtilization() int {
-	inUse := p.stats.totalGets.Load() - (p.stats.FastReturnHit.Load() + p.stats.FastReturnMiss.Load())
-	return (int(inUse) / p.pool.Capacity()) * 100
+	inUse := p.stats.inUse()
+	return (inUse / p.pool.Capacity()) * 100
 }
 
 func (p *Pool[T]) isUnderUtilized() bool {
@@ -167,6 +167,9 @@ func (p *Pool[T]) refill(fillTarget int) error {
 
 func (p *Pool[T]) createOnDemand(fillTarget int, spaceAvailable int) error {
 	allocAmount := p.config.allocationStrategy.AllocAmount
+	if p.config.allocationStrategy.Mode == AllocationLazy {
+		allocAmount = 1
+	}
 	allocAmount = min(allocAmount, spaceAvailable, fillTarget)
 
 	if allocAmount == 0 {
@@ -177,31 +180,26 @@ func (p *Pool[T]) createOnDemand(fillTarget int, spaceAvailable int) error {
 }
 
 func (p *Pool[T]) slowPathPut(obj T) error {
-	const maxRetries = 5
-	const retryDelay = 10 * time.Millisecond
-
-	var err error
-
-	for i := range maxRetries {
+	err := p.retryWithPolicy(func() error {
 		p.mu.RLock()
 		pool := p.pool
 		p.mu.RUnlock()
 
-		if err = pool.Write(obj); err == nil {
-			p.stats.FastReturnMiss.Add(1)
-			return nil
-		}
-
-		if i < maxRetries-1 {
-			time.Sleep(retryDelay)
-		}
+		return pool.Write(obj)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", errRingBufferFailed, err)
 	}
 
-	return fmt.Errorf("%w: %w", errRingBufferFailed, err)
+	p.stats.FastReturnMiss.Add(1)
+	return nil
 }
 
-func (p *Pool[T]) handleMaxConsecutiveShrinks(maxConsecutiveShrinks int) (cannotShrink bool) {
+// handleMaxConsecutiveShrinks parks the shrinker until the next growth once maxConsecutiveShrinks
+// is reached. The ticker is stopped while parked, the caller replaces it when true is returned.
+func (p *Pool[T]) handleMaxConsecutiveShrinks(maxConsecutiveShrinks int, ticker Ticker) (cannotShrink bool) {
 	if p.stats.consecutiveShrinks == maxConsecutiveShrinks {
+		ticker.Stop()
 		p.shrinkCond.Wait()
 		p.stats.consecutiveShrinks = 0
 		return true
@@ -210,8 +208,20 @@ func (p *Pool[T]) handleMaxConsecutiveShrinks(maxConsecutiveShrinks int) (cannot
 	return cannotShrink
 }
 
-func (p *Pool[T]) handleShrinkCooldown(shrinkCooldown time.Duration) (cooldownActive bool) {
-	timeSinceLastShrink := time.Since(p.stats.lastShrinkTime)
+// shrinkAllowed reports whether the maxConsecutiveShrinks limit and the shrink cooldown allow
+// a shrink at now. Unlike the background shrink, it doesn't wait for a growth once the limit is
+// reached. Must be called with p.mu held.
+func (p *Pool[T]) shrinkAllowed(now time.Time) bool {
+	params := p.config.shrink
+	if p.stats.consecutiveShrinks >= params.maxConsecutiveShrinks {
+		return false
+	}
+
+	return !p.handleShrinkCooldown(params.shrinkCooldown, now)
+}
+
+func (p *Pool[T]) handleShrinkCooldown(shrinkCooldown time.Duration, now time.Time) (cooldownActive bool) {
+	timeSinceLastShrink := now.Sub(p.stats.lastShrinkTime)
 	return timeSinceLastShrink < shrinkCooldown
 }
 
@@ -227,41 +237,69 @@ func (p *Pool[T]) tryRefill(fillTarget int) (bool, error) {
 // SlowPath retrieves an object from the ring buffer. It blocks if the ring buffer is empty
 // and the ring buffer is in blocking mode. We always try to refill the ring buffer before
 // calling the slow path.
+// Failed reads are retried according to the configured retry policy.
 func (p *Pool[T]) SlowPathGet() (obj T, err error) {
-	const maxRetries = 5
-	const retryDelay = 10 * time.Millisecond
-
-	for i := range maxRetries {
+	err = p.retryWithPolicy(func() error {
 		p.mu.RLock()
 		pool := p.pool
 		p.mu.RUnlock()
 
-		obj, err = pool.GetOne()
-		if err == nil {
-			p.stats.totalGets.Add(1)
-			return obj, nil
-		}
+		var getErr error
+		obj, getErr = pool.GetOne()
+		return getErr
+	})
+	if err != nil {
+		return obj, fmt.Errorf("%w: %w", errRingBufferFailed, err)
+	}
 
-		if i < maxRetries-1 {
-			time.Sleep(retryDelay)
-		}
+	p.stats.recordGet()
+	return obj, nil
+}
+
+// tryGetFromRingBuffer takes one object from the ring buffer if it has any, without blocking.
+func (p *Pool[T]) tryGetFromRingBuffer() (zero T, found bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pool.Length(false) == 0 {
+		return zero, false
+	}
+
+	part1, part2, err := p.pool.GetNView(1)
+	if err != nil && err != ringbufferInternalErrs.ErrIsEmpty {
+		return zero, false
+	}
+
+	var obj T
+	switch {
+	case len(part1) > 0:
+		obj = part1[0]
+	case len(part2) > 0:
+		obj = part2[0]
+	default:
+		return zero, false
 	}
 
-	return obj, fmt.Errorf("%w: %w", errRingBufferFailed, err)
+	p.stats.recordGet()
+	return obj, true
 }
 
 func (p *Pool[T]) RingBufferCapacity() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.pool.Capacity()
 }
 
 func (p *Pool[T]) RingBufferLength() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.pool.Length(false)
 }
 
 func (p *Pool[T]) hasOutstandingObjects() bool {
-	totalGets := p.stats.totalGets.Load()
-	totalReturns := p.stats.FastReturnHit.Load() + p.stats.FastReturnMiss.Load()
-	return totalReturns < totalGets
+	return p.stats.inUse() > 0
 }
 
 // closeAsync implements the waiting logic for closeAsync. It will attempt to wait for all
@@ -272,8 +310,7 @@ func (p *Pool[T]) closeAsync() {
 	attempts := 0
 
 	for attempts < maxAttempts {
-		totalReturns := p.stats.FastReturnHit.Load() + p.stats.FastReturnMiss.Load()
-		if totalReturns >= p.stats.totalGets.Load() {
+		if !p.hasOutstandingObjects() {
 			p.performClosure()
 			return
 		}
@@ -293,36 +330,45 @@ func (p *Pool[T]) closeAsync() {
 func (p *Pool[T]) performClosure() {
 	p.shrinkCond.Signal()
 	p.cancel()
+	p.leaveBudget()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.pool.Close()
 	p.cleanupCacheL1()
 }
 
 // GetBlockedReaders returns the number of readers currently blocked waiting for objects
 func (p *Pool[T]) GetBlockedReaders() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.pool.GetBlockedReaders()
 }
 
 // tryRefillAndGetL1 attempts to refill the pool, and get an object from L1 cache.
-// It will grow in case it's allowed and needed.
-func (p *Pool[T]) tryRefillAndFromGetL1() (zero T, canProceed bool) {
+// It will grow in case it's allowed and needed. If no object was found because the
+// allocator failed, the allocation error is returned.
+func (p *Pool[T]) tryRefillAndFromGetL1() (zero T, canProceed bool, allocErr error) {
 	select {
 	case p.refillSemaphore <- struct{}{}:
 		defer func() {
 			p.refillCond.Broadcast()
 			<-p.refillSemaphore
+			p.wakeWaiterIfStocked()
 		}()
-		obj, canProceed := p.handleRefillScenarios()
-		return obj, canProceed
+		return p.handleRefillScenarios()
 	default:
 		p.refillCond.L.Lock()
 		p.refillCond.Wait()
 		p.refillCond.L.Unlock()
 
 		if obj, found := p.tryGetFromL1(false); found {
-			return obj, true
+			return obj, true, nil
 		}
 
-		return zero, false
+		return zero, false, nil
 	}
 }
 
@@ -335,56 +381,59 @@ func (p *Pool[T]) tryGetFromL1IfWellStocked(currentPercent int) (obj T, found bo
 }
 
 // tryCreateAndGetFromL1 attempts to create new objects and get one from L1 cache
-func (p *Pool[T]) tryCreateAndGetFromL1(fillTarget int) (obj T, found bool) {
+func (p *Pool[T]) tryCreateAndGetFromL1(fillTarget int) (obj T, found bool, err error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	spaceAvailable := p.pool.Capacity() - (p.stats.objectsCreated - p.stats.objectsDestroyed)
 	if spaceAvailable <= 0 {
-		return obj, false
+		return obj, false, nil
 	}
 
-	if err := p.createOnDemand(fillTarget, spaceAvailable); err != nil {
-		return obj, false
-	}
+	err = p.createOnDemand(fillTarget, spaceAvailable)
 
-	return p.tryGetFromL1(true)
+	obj, found = p.tryGetFromL1(true)
+	return obj, found, err
 }
 
-// tryRefillAndGetFromL1 attempts to refill from main pool and get from L1 cache
-func (p *Pool[T]) tryRefillAndGetFromL1(fillTarget int) (obj T, found bool) {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
+// tryRefillAndGetFromL1 attempts to refill from main pool and get from L1 cache.
+// Refilling may grow the pool, replacing the ring buffer and the L1 channel, so it holds the write lock.
+func (p *Pool[T]) tryRefillAndGetFromL1(fillTarget int) (obj T, found bool, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
 	ableToRefill, err := p.tryRefill(fillTarget)
 	if !ableToRefill && err != nil {
 		if obj, shouldContinue := p.handleRefillFailure(err); !shouldContinue {
-			return obj, false
+			return obj, false, err
 		}
 	}
 
-	return p.tryGetFromL1(true)
+	obj, found = p.tryGetFromL1(true)
+	return obj, found, err
 }
 
-func (p *Pool[T]) handleRefillScenarios() (zero T, canProceed bool) {
+func (p *Pool[T]) handleRefillScenarios() (zero T, canProceed bool, allocErr error) {
 	p.mu.RLock()
 	currentCap, currentPercent := p.calculateL1Usage()
 	fillTarget := p.calculateFillTarget(currentCap)
 	p.mu.RUnlock()
 
 	if obj, found := p.tryGetFromL1IfWellStocked(currentPercent); found {
-		return obj, true
+		return obj, true, nil
 	}
 
-	if obj, found := p.tryCreateAndGetFromL1(fillTarget); found {
-		return obj, true
+	obj, found, createErr := p.tryCreateAndGetFromL1(fillTarget)
+	if found {
+		return obj, true, nil
 	}
 
-	if obj, found := p.tryRefillAndGetFromL1(fillTarget); found {
-		return obj, true
+	obj, found, refillErr := p.tryRefillAndGetFromL1(fillTarget)
+	if found {
+		return obj, true, nil
 	}
 
-	return zero, false
+	return zero, false, allocationError(createErr, refillErr)
 }
 
 func checkConfigForNil[T any](config *PoolConfig[T]) error {
@@ -404,6 +453,34 @@ func checkConfigForNil[T any](config *PoolConfig[T]) error {
 		return errNilConfig
 	}
 
+	if config.backgroundFill == nil {
+		return errNilConfig
+	}
+
+	if config.waitQueue == nil {
+		return errNilConfig
+	}
+
+	if config.adaptiveFastPath == nil {
+		return errNilConfig
+	}
+
+	if config.objectTracking == nil {
+		return errNilConfig
+	}
+
+	if config.holdTimeout == nil {
+		return errNilConfig
+	}
+
+	if config.retryPolicy == nil {
+		return errNilConfig
+	}
+
+	if config.clock == nil {
+		return errNilConfig
+	}
+
 	return nil
 }
 
@@ -417,10 +494,14 @@ func (p *Pool[T]) handleRefillFailure(refillError error) (T, bool) {
 }
 
 func (p *Pool[T]) IsRingBufferShrunk() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	return p.stats.currentCapacity < p.config.initialCapacity
 }
 
 func (p *Pool[T]) IsFastPathShrunk() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	return p.stats.currentL1Capacity < p.config.fastPath.initialSize
 }
 
@@ -443,3 +524,15 @@ func (p *Pool[T]) IsFastPathGrowth() bool {
 func (p *Pool[T]) IsGrowth() bool {
 	return p.IsRingBufferGrowth() || p.IsFastPathGrowth()
 }
+
+// wakeWaiterIfStocked wakes the head of the wait queue if a refill left objects in L1
+// that no Put will hand over.
+func (p *Pool[T]) wakeWaiterIfStocked() {
+	p.mu.RLock()
+	stocked := len(*p.cacheL1) > 0
+	p.mu.RUnlock()
+
+	if stocked {
+		p.waiters.wakeHead()
+	}
+}
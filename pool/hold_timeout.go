@@ -0,0 +1,62 @@
+package pool
+
+// enforceHoldTimeout is a background goroutine that reports the objects held longer than the
+// max hold time every check interval and, with reclaim, replaces them.
+func (p *Pool[T]) enforceHoldTimeout() {
+	params := p.config.holdTimeout
+	ticker := p.config.clock.NewTicker(params.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		expired := p.tracker.expired(params.maxHoldTime, params.reclaim)
+		for _, held := range expired {
+			if params.reclaim {
+				p.reclaim()
+			}
+
+			if p.config.onHoldTimeout != nil {
+				p.config.onHoldTimeout(held.obj.(T), held.holder)
+			}
+		}
+	}
+}
+
+// reclaim stops counting an object that exceeded the max hold time as in use and
+// allocates a replacement, if the pool has room for it. The holder's object is cleaned
+// and dropped by Put when it's returned.
+func (p *Pool[T]) reclaim() {
+	p.stats.objectsInUse.Add(-1)
+
+	p.mu.Lock()
+	p.stats.objectsDestroyed++
+	p.stats.reclaimedObjects.Add(1)
+
+	live := p.stats.objectsCreated - p.stats.objectsDestroyed
+	reserved := 0
+	if live < min(p.stats.currentCapacity, p.config.hardLimit) {
+		p.stats.objectsCreated++
+		reserved = 1
+	}
+	p.mu.Unlock()
+
+	if reserved == 0 {
+		return
+	}
+
+	var objs []T
+	if obj, err := p.newObject(); err == nil {
+		objs = append(objs, obj)
+	}
+
+	p.handInBackgroundFill(objs, reserved)
+
+	p.mu.RLock()
+	p.pool.WakeUpOneReader()
+	p.mu.RUnlock()
+}
@@ -1,6 +1,7 @@
 package pool
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sync"
@@ -14,26 +15,37 @@ import (
 // statistics, shrink/growth parameters, fast path settings, and ring buffer configuration.
 // Returns a fully configured PoolConfig instance.
 func createDefaultConfig[T any]() *PoolConfig[T] {
+	// The shrink parameters are updated below, so they're copied to keep the shared defaults intact.
+	copiedShrink := *defaultShrinkParameters
+	copiedFastPath := *defaultFastPath
+
 	pgb := &poolConfigBuilder[T]{
 		config: &PoolConfig[T]{
 			initialCapacity: defaultPoolCapacity,
 			hardLimit:       defaultHardLimit,
-			shrink:          defaultShrinkParameters,
+			shrink:          &copiedShrink,
 			growth:          defaultGrowthParameters,
-			fastPath:        defaultFastPath,
+			fastPath:        &copiedFastPath,
 			ringBufferConfig: &config.RingBufferConfig[T]{
 				Block:    Block,
 				RTimeout: RTimeout,
 				WTimeout: WTimeout,
 			},
 			allocationStrategy: defaultAllocationStrategy,
+			backgroundFill:     defaultBackgroundFill,
+			waitQueue:          defaultWaitQueue,
+			adaptiveFastPath:   defaultAdaptiveFastPath,
+			retryPolicy:        defaultRetryPolicy,
+			objectTracking:     defaultObjectTracking,
+			holdTimeout:        defaultHoldTimeout,
+			clock:              defaultClock,
 		},
 	}
 
 	pgb.config.shrink.ApplyDefaults(getShrinkDefaultsMap())
 
-	copiedShrink := *pgb.config.shrink
-	pgb.config.fastPath.shrink = &copiedShrink
+	copiedL1Shrink := *pgb.config.shrink
+	pgb.config.fastPath.shrink = &copiedL1Shrink
 	pgb.config.fastPath.shrink.minCapacity = defaultL1MinCapacity
 
 	return pgb.config
@@ -43,29 +55,24 @@ func createDefaultConfig[T any]() *PoolConfig[T] {
 // the provided configuration values. It sets up initial capacity values for both
 // the main pool and L1 cache.
 func initializePoolStats[T any](config *PoolConfig[T]) *poolStats {
-	stats := &poolStats{mu: sync.RWMutex{}}
+	stats := &poolStats{}
 	stats.initialCapacity = config.initialCapacity
-	stats.currentCapacity = config.initialCapacity
-	stats.currentL1Capacity = config.fastPath.initialSize
+	stats.currentCapacity, stats.currentL1Capacity = config.restoredCapacities()
+	stats.restoreCounters(config.initialState)
 	return stats
 }
 
-// validateType validates the provided allocator, cleaner, and cloneTemplate functions.
+// validate validates an object returned by the allocator and the cloneTemplate function.
 // This is a critical validation as the pool requires pointer types for proper object management.
-// Returns an error if the allocator returns a non-pointer type.
-func validate[T any](allocator func() T, cleaner func(T), cloner func(T) T) error {
-	var zero T
-	if reflect.TypeOf(zero).Kind() != reflect.Ptr {
-		return fmt.Errorf("type T must be a pointer type, got %T", zero)
-	}
-
-	obj := allocator()
+// Returns an error if the allocated object is nil or it or the cloned object is not a pointer type.
+// The rules that don't need an object are checked by validateType at construction.
+func validate[T any](obj T, cloner func(T) T) error {
 	if reflect.TypeOf(obj).Kind() != reflect.Ptr {
 		return fmt.Errorf("type returned by allocator must be a pointer type, got %T", obj)
 	}
 
-	if cleaner == nil {
-		return fmt.Errorf("cleaner function is nil")
+	if isNilObject(obj) {
+		return fmt.Errorf("%w: allocator returned a nil %T", errNilObject, obj)
 	}
 
 	if cloner != nil {
@@ -77,14 +84,29 @@ func validate[T any](allocator func() T, cleaner func(T), cloner func(T) T) erro
 	return nil
 }
 
+// validateType checks the rules that don't need an object: T must be a pointer type
+// and the cleaner must be set.
+func validateType[T any](cleaner func(T)) error {
+	var zero T
+	if reflect.TypeOf(zero).Kind() != reflect.Ptr {
+		return fmt.Errorf("type T must be a pointer type, got %T", zero)
+	}
+
+	if cleaner == nil {
+		return errNilCleaner
+	}
+
+	return nil
+}
+
 // initializePoolObject creates and initializes a new Pool instance with the provided
 // configuration, allocator, cleaner, and ring buffer. It sets up the L1 cache channel
-// and initializes all necessary synchronization primitives.
+// and initializes all necessary synchronization primitives. The template is set once the
+// first object was allocated.
 // Returns a fully initialized Pool instance or an error if initialization fails.
-func initializePoolObject[T any](config *PoolConfig[T], allocator func() T, cleaner func(T), cloneTemplate func(T) T, stats *poolStats, ringBuffer *ringbuffer.RingBuffer[T]) (*Pool[T], error) {
-	ch := make(chan T, config.fastPath.initialSize)
+func initializePoolObject[T any](config *PoolConfig[T], allocator func(context.Context) (T, error), cleaner func(T) T, cloneTemplate func(T) T, stats *poolStats, ringBuffer *ringbuffer.RingBuffer[T]) (*Pool[T], error) {
+	ch := make(chan T, stats.currentL1Capacity)
 
-	template := allocator()
 	poolObj := &Pool[T]{
 		cacheL1:         &ch,
 		refillSemaphore: make(chan struct{}, 1),
@@ -94,8 +116,10 @@ func initializePoolObject[T any](config *PoolConfig[T], allocator func() T, clea
 		config:          config,
 		stats:           stats,
 		pool:            ringBuffer,
-		template:        template,
 		refillCond:      sync.NewCond(&sync.Mutex{}),
+		fillSignal:      make(chan struct{}, 1),
+		waiters:         newWaitQueue[T](config.waitQueue.maxWaiters),
+		tracker:         newObjectTracker(config.objectTracking, config.clock),
 	}
 
 	poolObj.shrinkCond = sync.NewCond(&poolObj.mu)
@@ -105,18 +129,22 @@ func initializePoolObject[T any](config *PoolConfig[T], allocator func() T, clea
 // populateL1OrBuffer initializes the pool by creating and distributing objects between
 // the L1 cache and main buffer. It uses the configured fill aggressiveness to determine
 // how many objects should go to the L1 cache versus the main buffer.
+// Objects created before a failure stay in the pool and are counted in objectsCreated.
 // Returns an error if object allocation or distribution fails.
 func (p *Pool[T]) populateL1OrBuffer(allocAmount int) error {
-	fillTarget := p.config.fastPath.initialSize * p.config.fastPath.fillAggressiveness / 100
-	fastPathRemaining := fillTarget
-
-	for range allocAmount {
-		var obj T
-		if p.cloneTemplate != nil {
-			obj = p.cloneTemplate(p.template)
-		} else {
-			obj = p.allocator()
-		}
+	return p.distributeNewObjects(allocAmount, p.initialFillTarget())
+}
+
+// initialFillTarget returns how many objects populateL1OrBuffer places in the L1 cache.
+func (p *Pool[T]) initialFillTarget() int {
+	return p.config.fastPath.initialSize * p.config.fastPath.fillAggressiveness / 100
+}
+
+// distributeNewObjects creates allocAmount objects, placing the first fastPathRemaining of
+// them in the L1 cache and the others in the main buffer.
+func (p *Pool[T]) distributeNewObjects(allocAmount, fastPathRemaining int) error {
+	objs, allocErr := p.newObjects(allocAmount)
+	for _, obj := range objs {
 		p.stats.objectsCreated++
 
 		var err error
@@ -125,7 +153,30 @@ func (p *Pool[T]) populateL1OrBuffer(allocAmount int) error {
 			return fmt.Errorf("failed to set pool and buffer: %w", err)
 		}
 	}
-	return nil
+	return allocErr
+}
+
+// discardInitialObjects cleans the objects created by a failed initialization and cancels
+// the pool's context, since the pool is never handed to the caller.
+func (p *Pool[T]) discardInitialObjects() {
+	p.cancel()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cleanupCacheL1()
+
+	part1, part2, err := p.pool.GetAllView()
+	if err == nil {
+		for _, obj := range part1 {
+			p.cleaner(obj)
+		}
+		for _, obj := range part2 {
+			p.cleaner(obj)
+		}
+	}
+
+	p.pool.Close()
 }
 
 // cleanupCacheL1 performs cleanup of the L1 cache by:
@@ -0,0 +1,333 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	errUnknownKey      = errors.New("no pool exists for key")
+	errKeyedPoolClosed = errors.New("keyed pool is closed")
+	errNotCheckedOut   = errors.New("no object is checked out for key")
+)
+
+// minEvictionInterval bounds how often the idle keys are checked, so very short idle
+// timeouts don't turn the eviction into a busy loop.
+const minEvictionInterval = time.Millisecond
+
+// KeyedPool manages one Pool per key, such as a downstream host or a tenant.
+// Pools are created lazily from a shared configuration the first time a key is used,
+// share a global hard limit, and are closed once their key stays idle.
+type KeyedPool[K comparable, T any] struct {
+	mu sync.Mutex
+
+	// pools holds the pool of every active key.
+	pools map[K]*keyedEntry[T]
+
+	// config is the configuration every per-key pool is created from.
+	config *PoolConfig[T]
+
+	allocator func() T
+	cleaner   func(T)
+	cloner    func(T) T
+
+	// globalHardLimit is the maximum number of objects held across all keys, zero means no limit.
+	globalHardLimit int
+
+	// idleTimeout is how long a key must go unused, with no objects in use, before it's evicted.
+	// Zero disables eviction.
+	idleTimeout time.Duration
+
+	// clock is the clock of config, used to track key usage and drive the eviction.
+	clock Clock
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// keyedEntry is the pool of a single key along with its usage tracking.
+type keyedEntry[T any] struct {
+	pool *Pool[T]
+
+	// inUse counts the objects of this key that haven't been returned yet.
+	inUse atomic.Int64
+
+	// lastUsed holds the unix nano time of the last Get or Put for this key.
+	lastUsed atomic.Int64
+}
+
+// NewKeyedPool creates a keyed pool whose per-key pools are created from config, allocator,
+// cleaner and cloner, as with NewPool. The hard limit of config applies to each key, while
+// globalHardLimit bounds the objects held across all keys: like the hard limit of a pool, it
+// applies to the capacities of the per-key pools, which share it through a Budget counting every
+// object as one unit. A key whose pool needs room the others can't give up, even by shrinking,
+// fails to grow or, for a new key, to get a pool. Keys that stay idle for idleTimeout with no
+// objects in use are evicted and their pool closed.
+//
+// Zero values disable the global hard limit and the eviction respectively.
+func NewKeyedPool[K comparable, T any](config *PoolConfig[T], globalHardLimit int, idleTimeout time.Duration, allocator func() T, cleaner func(T), cloner func(T) T) (*KeyedPool[K, T], error) {
+	if globalHardLimit < 0 {
+		return nil, fmt.Errorf("globalHardLimit must be >= 0, got %d", globalHardLimit)
+	}
+
+	if idleTimeout < 0 {
+		return nil, fmt.Errorf("idleTimeout must be >= 0, got %v", idleTimeout)
+	}
+
+	if allocator == nil {
+		return nil, errNilAllocator
+	}
+
+	if err := validateType(cleaner); err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		config = createDefaultConfig[T]()
+	}
+
+	clock := defaultClock
+	if config.clock != nil {
+		clock = config.clock
+	}
+
+	if globalHardLimit > 0 {
+		objects, err := NewBudget(int64(globalHardLimit))
+		if err != nil {
+			return nil, err
+		}
+
+		shared := *config
+		shared.objectBudget = objects
+		config = &shared
+	}
+
+	kp := &KeyedPool[K, T]{
+		pools:           make(map[K]*keyedEntry[T]),
+		config:          config,
+		allocator:       allocator,
+		cleaner:         cleaner,
+		cloner:          cloner,
+		globalHardLimit: globalHardLimit,
+		idleTimeout:     idleTimeout,
+		clock:           clock,
+	}
+
+	kp.ctx, kp.cancel = context.WithCancel(context.Background())
+
+	if idleTimeout > 0 {
+		go kp.evictIdle()
+	}
+
+	return kp, nil
+}
+
+// Get retrieves an object from the pool of key, creating that pool if needed.
+// Returns ErrHardLimitExceeded if the global hard limit leaves no room for a new pool.
+func (kp *KeyedPool[K, T]) Get(key K) (zero T, err error) {
+	entry, err := kp.acquireEntry(key)
+	if err != nil {
+		return zero, err
+	}
+
+	obj, err := entry.pool.Get()
+	if err != nil {
+		entry.inUse.Add(-1)
+		return zero, err
+	}
+
+	return obj, nil
+}
+
+// Put returns an object to the pool of key.
+// Returns an error if key has no pool or none of its objects is checked out.
+func (kp *KeyedPool[K, T]) Put(key K, obj T) error {
+	kp.mu.Lock()
+	entry, ok := kp.pools[key]
+	kp.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %v", errUnknownKey, key)
+	}
+
+	if !entry.release() {
+		return fmt.Errorf("%w: %v", errNotCheckedOut, key)
+	}
+
+	err := entry.pool.Put(obj)
+	entry.lastUsed.Store(kp.clock.Now().UnixNano())
+
+	return err
+}
+
+// Len returns the number of keys that currently have a pool.
+func (kp *KeyedPool[K, T]) Len() int {
+	kp.mu.Lock()
+	defer kp.mu.Unlock()
+	return len(kp.pools)
+}
+
+// Pool returns the pool of key, if one exists.
+func (kp *KeyedPool[K, T]) Pool(key K) (*Pool[T], bool) {
+	kp.mu.Lock()
+	defer kp.mu.Unlock()
+
+	entry, ok := kp.pools[key]
+	if !ok {
+		return nil, false
+	}
+	return entry.pool, true
+}
+
+// GetPoolStatsSnapshot returns the statistics of all keys combined into a single snapshot.
+func (kp *KeyedPool[K, T]) GetPoolStatsSnapshot() *PoolStatsSnapshot {
+	kp.mu.Lock()
+	snapshots := make([]*PoolStatsSnapshot, 0, len(kp.pools))
+	for _, entry := range kp.pools {
+		snapshots = append(snapshots, entry.pool.GetPoolStatsSnapshot())
+	}
+	kp.mu.Unlock()
+
+	return mergeSnapshots(snapshots)
+}
+
+// Close stops the eviction and closes the pool of every key. The pools are closed
+// concurrently and stay reachable while closing, so objects still in use can be put back.
+func (kp *KeyedPool[K, T]) Close() error {
+	kp.cancel()
+
+	kp.mu.Lock()
+	entries := make([]*keyedEntry[T], 0, len(kp.pools))
+	for _, entry := range kp.pools {
+		entries = append(entries, entry)
+	}
+	kp.mu.Unlock()
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(entries))
+	)
+	for i, entry := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = entry.pool.Close()
+		}()
+	}
+	wg.Wait()
+
+	kp.mu.Lock()
+	kp.pools = make(map[K]*keyedEntry[T])
+	kp.mu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// acquireEntry returns the entry of key, creating its pool if needed, and marks one of its
+// objects as in use so the entry can't be evicted until it's returned.
+// New pools are created without holding the lock, so other keys aren't blocked by the allocator.
+func (kp *KeyedPool[K, T]) acquireEntry(key K) (*keyedEntry[T], error) {
+	kp.mu.Lock()
+	entry, err := kp.lockedAcquireEntry(key)
+	kp.mu.Unlock()
+
+	if entry != nil || err != nil {
+		return entry, err
+	}
+
+	p, err := NewPool(kp.config, kp.allocator, kp.cleaner, kp.cloner)
+	if errors.Is(err, ErrBudgetExhausted) {
+		return nil, fmt.Errorf("%w: no room for the pool of key %v within %d objects across all keys", ErrHardLimitExceeded, key, kp.globalHardLimit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pool for key %v: %w", key, err)
+	}
+	created := &keyedEntry[T]{pool: p.(*Pool[T])}
+
+	kp.mu.Lock()
+	entry, err = kp.lockedAcquireEntry(key)
+	if entry == nil && err == nil {
+		kp.pools[key] = created
+		entry, err = kp.lockedAcquireEntry(key)
+	}
+	kp.mu.Unlock()
+
+	if entry != created {
+		// Another Get created the key's pool first, or the keyed pool was closed meanwhile.
+		created.pool.Close()
+	}
+
+	return entry, err
+}
+
+// lockedAcquireEntry marks an object of the existing entry of key as in use and returns
+// the entry, or nil if key has no pool yet. Must be called with kp.mu held.
+func (kp *KeyedPool[K, T]) lockedAcquireEntry(key K) (*keyedEntry[T], error) {
+	if kp.ctx.Err() != nil {
+		return nil, errKeyedPoolClosed
+	}
+
+	entry, ok := kp.pools[key]
+	if !ok {
+		return nil, nil
+	}
+
+	entry.inUse.Add(1)
+	entry.lastUsed.Store(kp.clock.Now().UnixNano())
+
+	return entry, nil
+}
+
+// release marks one object of the entry as returned. Returns false, leaving the count
+// untouched, if none of its objects is checked out.
+func (e *keyedEntry[T]) release() bool {
+	for {
+		inUse := e.inUse.Load()
+		if inUse <= 0 {
+			return false
+		}
+
+		if e.inUse.CompareAndSwap(inUse, inUse-1) {
+			return true
+		}
+	}
+}
+
+// evictIdle is a background goroutine that periodically closes the pools of idle keys.
+func (kp *KeyedPool[K, T]) evictIdle() {
+	ticker := kp.clock.NewTicker(max(kp.idleTimeout/2, minEvictionInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-kp.ctx.Done():
+			return
+		case <-ticker.C():
+			kp.evictIdleKeys()
+		}
+	}
+}
+
+// evictIdleKeys removes the keys that have been idle for idleTimeout with no objects
+// in use and closes their pools.
+func (kp *KeyedPool[K, T]) evictIdleKeys() {
+	deadline := kp.clock.Now().Add(-kp.idleTimeout).UnixNano()
+
+	kp.mu.Lock()
+	var evicted []*keyedEntry[T]
+	for key, entry := range kp.pools {
+		if entry.inUse.Load() == 0 && entry.lastUsed.Load() < deadline {
+			delete(kp.pools, key)
+			evicted = append(evicted, entry)
+		}
+	}
+	kp.mu.Unlock()
+
+	for _, entry := range evicted {
+		entry.pool.Close()
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/AlexsanderHamir/ringbuffer"
 )
@@ -15,6 +14,24 @@ var (
 	errNoItemsToMove    = errors.New("no items to move")
 	errNilObject        = errors.New("object is nil")
 	errNilConfig        = errors.New("config is nil")
+	errNilAllocator     = errors.New("allocator function is nil")
+	errNilCleaner       = errors.New("cleaner function is nil")
+	errPoolClosed       = errors.New("pool is closed")
+
+	// ErrInvalidCapacity is returned when a requested capacity or amount is not usable by the pool.
+	ErrInvalidCapacity = errors.New("invalid capacity")
+
+	// ErrHardLimitExceeded is returned when an operation would push the pool above its hard limit.
+	ErrHardLimitExceeded = errors.New("hard limit exceeded")
+
+	// ErrShrinkRejected is returned when an on-demand shrink is prevented by the shrink limits.
+	ErrShrinkRejected = errors.New("shrink rejected")
+
+	// ErrAllocationFailed is returned when the allocator fails to create a new object.
+	ErrAllocationFailed = errors.New("allocation failed")
+
+	// ErrTooManyWaiters is returned when the wait queue already holds the maximum number of callers.
+	ErrTooManyWaiters = errors.New("too many waiters")
 )
 
 // NewPool creates a new object pool with the given configuration.
@@ -27,10 +44,54 @@ var (
 // delaying the initialization of the object state, which you will be responsible for in case of reference types,
 // otherwise all instances will share the same reference types.
 func NewPool[T any](config *PoolConfig[T], allocator func() T, cleaner func(T), cloner func(T) T) (PoolObj[T], error) {
-	if err := validate(allocator, cleaner, cloner); err != nil {
+	if allocator == nil {
+		return nil, errNilAllocator
+	}
+
+	if err := validateType(cleaner); err != nil {
 		return nil, err
 	}
 
+	fallibleAllocator := func(context.Context) (T, error) {
+		return allocator(), nil
+	}
+
+	validateObject := func(obj T) error {
+		return validate(obj, cloner)
+	}
+
+	return newPool(config, fallibleAllocator, inPlaceCleaner(cleaner), cloner, validateObject)
+}
+
+// NewPoolWithFallibleAllocator creates a new object pool whose allocator can fail, such as one
+// opening connections or file handles.
+//
+// The allocator receives the pool's context, which is canceled when the pool is closed.
+// Allocation errors are returned by Get wrapped with ErrAllocationFailed when no other object
+// is available, and the pool stops allocating for a while after repeated failures if
+// SetAllocationFailureBackoff is configured.
+//
+// The cleaner and cloner functions behave as in NewPool.
+func NewPoolWithFallibleAllocator[T any](config *PoolConfig[T], allocator func(ctx context.Context) (T, error), cleaner func(T), cloner func(T) T) (PoolObj[T], error) {
+	if allocator == nil {
+		return nil, errNilAllocator
+	}
+
+	if err := validateType(cleaner); err != nil {
+		return nil, err
+	}
+
+	validateObject := func(obj T) error {
+		return validate(obj, cloner)
+	}
+
+	return newPool(config, allocator, inPlaceCleaner(cleaner), cloner, validateObject)
+}
+
+// newPool holds the construction logic shared by all pool constructors, which check the
+// rules that don't need an object before calling it. validateObject checks an object returned
+// by the allocator against the constructor's rules, on the first allocation.
+func newPool[T any](config *PoolConfig[T], allocator func(context.Context) (T, error), cleaner func(T) T, cloner func(T) T, validateObject func(T) error) (PoolObj[T], error) {
 	if config == nil {
 		config = createDefaultConfig[T]()
 	}
@@ -39,13 +100,13 @@ func NewPool[T any](config *PoolConfig[T], allocator func() T, cleaner func(T),
 		return nil, err
 	}
 
-	ringBuffer, err := ringbuffer.NewWithConfig(config.initialCapacity, config.ringBufferConfig)
+	stats := initializePoolStats(config)
+
+	ringBuffer, err := ringbuffer.NewWithConfig(stats.currentCapacity, config.ringBufferConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	stats := initializePoolStats(config)
-
 	poolObj, err := initializePoolObject(config, allocator, cleaner, cloner, stats, ringBuffer)
 	if err != nil {
 		return nil, err
@@ -55,47 +116,183 @@ func NewPool[T any](config *PoolConfig[T], allocator func() T, cleaner func(T),
 
 	poolObj.ctx, poolObj.cancel = context.WithCancel(context.Background())
 
-	allocationStrategy := poolObj.config.allocationStrategy
-	preAllocAmount := poolObj.stats.currentCapacity * allocationStrategy.AllocPercent / 100
+	if config.objectBudget != nil {
+		if err := poolObj.joinObjectBudget(config.objectBudget); err != nil {
+			poolObj.cancel()
+			return nil, err
+		}
+	}
 
-	if err := poolObj.populateL1OrBuffer(preAllocAmount); err != nil {
+	if config.allocationStrategy.Mode == AllocationLazy {
+		// Nothing is allocated up front, the first allocation validates the allocator instead.
+		poolObj.validateObject = validateObject
+		poolObj.firstObjectPending.Store(true)
+	} else if err := poolObj.preallocate(validateObject); err != nil {
+		poolObj.leaveBudget()
 		return nil, err
 	}
 
 	go poolObj.shrink()
 
+	if poolObj.config.backgroundFill.enabled {
+		go poolObj.backgroundFill()
+	}
+
+	if poolObj.config.adaptiveFastPath.enabled {
+		go poolObj.adaptFastPath()
+	}
+
+	if poolObj.config.holdTimeout.enabled {
+		go poolObj.enforceHoldTimeout()
+	}
+
 	return poolObj, nil
 }
 
+// preallocate allocates the objects of the non-lazy modes at construction. The first object
+// validates the allocator and is kept as the first pooled object.
+func (p *Pool[T]) preallocate(validateObject func(T) error) error {
+	obj, err := p.allocator(p.ctx)
+	if err != nil {
+		p.cancel()
+		return fmt.Errorf("%w: first object: %w", ErrAllocationFailed, err)
+	}
+
+	if err := validateObject(obj); err != nil {
+		p.discardInvalidObject(obj)
+		p.cancel()
+		return err
+	}
+
+	if p.cloneTemplate != nil {
+		p.template = p.cloneTemplate(obj)
+	}
+
+	p.tracker.track(obj)
+	p.stats.objectsCreated++
+
+	// The first object counts towards the L1 fill target like the objects allocated after it.
+	fastPathRemaining, err := p.setPoolAndBuffer(obj, p.initialFillTarget())
+	if err != nil {
+		p.discardInitialObjects()
+		return err
+	}
+
+	if err := p.distributeNewObjects(p.preallocAmount(p.stats.currentCapacity)-1, fastPathRemaining); err != nil {
+		p.discardInitialObjects()
+		return err
+	}
+
+	return nil
+}
+
 // Get returns an object from the pool, either from L1 cache or the ring buffer, preferring L1.
+// If no object is available and the allocator failed, the error wraps ErrAllocationFailed.
 func (p *Pool[T]) Get() (zero T, err error) {
+	obj, err := p.get()
+	if err != nil {
+		return zero, err
+	}
+
+	p.tracker.checkout(obj, 1)
+	return obj, nil
+}
+
+func (p *Pool[T]) get() (zero T, err error) {
 	if obj, found := p.tryGetFromL1(false); found {
 		return obj, nil
 	}
 
-	if obj, found := p.tryRefillAndFromGetL1(); found {
+	p.stats.l1GetMisses.Add(1)
+	p.notifyBackgroundFill()
+
+	obj, found, allocErr := p.tryRefillAndFromGetL1()
+	if found {
 		return obj, nil
 	}
 
-	obj, err := p.SlowPathGet()
+	if p.config.waitQueue.enabled {
+		return p.getFromWaitQueue(allocErr)
+	}
+
+	obj, err = p.SlowPathGet()
 	if err != nil {
+		if allocErr != nil {
+			return zero, allocErr
+		}
 		return zero, err
 	}
 
 	return obj, nil
 }
 
+// TryGet returns an object from the pool without ever waiting, regardless of the ring buffer
+// blocking configuration. It checks L1, creates objects on demand within the pool limits and
+// checks the ring buffer, returning false if none of them yields an object.
+func (p *Pool[T]) TryGet() (zero T, ok bool) {
+	obj, ok := p.tryGet()
+	if !ok {
+		return zero, false
+	}
+
+	p.tracker.checkout(obj, 1)
+	return obj, true
+}
+
+func (p *Pool[T]) tryGet() (zero T, ok bool) {
+	if obj, found := p.tryGetFromL1(false); found {
+		return obj, true
+	}
+
+	p.stats.l1GetMisses.Add(1)
+
+	if obj, found, _ := p.tryGetWithoutWaiting(); found {
+		return obj, true
+	}
+
+	return p.tryGetFromRingBuffer()
+}
+
 // Put returns an object to the pool. The object will be cleaned using the cleaner function
-// before being made available for reuse.
+// before being made available for reuse, and handed directly to the first queued waiter if any.
+// With object tracking, objects that reached maxReuses are retired instead, and objects
+// reclaimed after a hold timeout are cleaned and dropped.
 func (p *Pool[T]) Put(obj T) error {
 	defer func() {
 		p.refillCond.Signal()
+		p.waiters.wakeHead()
 	}()
 
-	p.cleaner(obj)
+	switch p.tracker.checkin(obj) {
+	case checkinRetire:
+		p.stats.objectsInUse.Add(-1)
+		p.retire()
+		return nil
+	case checkinDiscard:
+		// Reclaimed after a hold timeout and already counted as destroyed.
+		p.cleaner(obj)
+		return nil
+	}
+
+	obj = p.cleaner(obj)
+	p.stats.objectsInUse.Add(-1)
+
+	return p.store(obj)
+}
+
+// store makes a clean object available again, handing it to the first queued waiter if any,
+// or storing it in L1 or the ring buffer otherwise.
+func (p *Pool[T]) store(obj T) error {
+	if p.waiters.handOff(obj) {
+		p.stats.recordGet()
+		p.stats.FastReturnHit.Add(1)
+		return nil
+	}
 
 	if p.tryFastPathPut(obj) {
+		p.mu.RLock()
 		p.pool.WakeUpOneReader()
+		p.mu.RUnlock()
 		return nil
 	}
 
@@ -117,8 +314,10 @@ func (p *Pool[T]) Close() error {
 // and shrinks the pool if necessary to free up memory.
 func (p *Pool[T]) shrink() {
 	params := p.config.shrink
-	ticker := time.NewTicker(params.checkInterval)
-	defer ticker.Stop()
+	ticker := p.config.clock.NewTicker(params.checkInterval)
+	defer func() {
+		ticker.Stop()
+	}()
 
 	var (
 		underutilCount int
@@ -129,15 +328,16 @@ func (p *Pool[T]) shrink() {
 		select {
 		case <-p.ctx.Done():
 			return
-		case <-ticker.C:
+		case now := <-ticker.C():
 			p.mu.Lock()
 
-			if p.handleMaxConsecutiveShrinks(params.maxConsecutiveShrinks) {
+			if p.handleMaxConsecutiveShrinks(params.maxConsecutiveShrinks, ticker) {
 				p.mu.Unlock()
+				ticker = p.config.clock.NewTicker(params.checkInterval)
 				continue
 			}
 
-			if p.handleShrinkCooldown(params.shrinkCooldown) {
+			if p.handleShrinkCooldown(params.shrinkCooldown, now) {
 				p.mu.Unlock()
 				continue
 			}
@@ -154,7 +354,7 @@ func (p *Pool[T]) shrink() {
 			}
 
 			if utilOK {
-				p.shrinkExecution()
+				p.shrinkExecution(now)
 				underutilCount = 0
 				utilOK = false
 			}
@@ -175,6 +375,17 @@ func (p *Pool[T]) grow() error {
 		return errGrowthBlocked
 	}
 
+	oldCapacity := p.stats.currentCapacity
+	if oldCapacity >= p.config.hardLimit {
+		// Already at the hard limit, e.g. restored there by SetInitialState, so there is
+		// nothing to grow. Block growth as a growth reaching the limit does.
+		p.isGrowthBlocked.Store(true)
+		if err := p.fillRemainingCapacity(oldCapacity); err != nil {
+			return fmt.Errorf("failed to fill remaining capacity: %w", err)
+		}
+		return nil
+	}
+
 	newCapacity := p.calculateNewPoolCapacity()
 
 	if err := p.updatePoolCapacity(newCapacity); err != nil {
@@ -182,11 +393,20 @@ func (p *Pool[T]) grow() error {
 	}
 
 	p.stats.totalGrowthEvents++
+	p.recordCapacityEvent(CapacityGrowth, oldCapacity, p.stats.currentCapacity)
+
+	// The growth is complete even if filling it fails, the objects are allocated on later demand.
+	fillErr := p.fillRemainingCapacity(p.stats.currentCapacity)
+
 	err := p.tryL1ResizeIfTriggered()
 	if err != nil {
 		return err
 	}
 
+	if fillErr != nil {
+		return fmt.Errorf("failed to fill remaining capacity: %w", fillErr)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,196 @@
+package pool
+
+import (
+	"fmt"
+)
+
+// Prewarm allocates up to n new objects ahead of demand, growing the ring buffer if it
+// can't hold them. It's meant to be called before a known traffic spike so the pool doesn't
+// have to wait for misses to trigger growth.
+//
+// Returns ErrInvalidCapacity if n is not positive, ErrHardLimitExceeded if the allocation
+// would push the number of live objects above the hard limit, and an error if the pool is closed.
+func (p *Pool[T]) Prewarm(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("%w: prewarm amount must be greater than 0, got %d", ErrInvalidCapacity, n)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ctx.Err() != nil {
+		return errPoolClosed
+	}
+
+	live := p.stats.objectsCreated - p.stats.objectsDestroyed
+	required := live + n
+	if required > p.config.hardLimit {
+		return fmt.Errorf("%w: prewarm of %d objects requires %d, hard limit is %d", ErrHardLimitExceeded, n, required, p.config.hardLimit)
+	}
+
+	if required > p.stats.currentCapacity {
+		if err := p.resizeRingBuffer(required); err != nil {
+			return err
+		}
+	}
+
+	err := p.populateL1OrBuffer(n)
+	p.waiters.wakeHead()
+	return err
+}
+
+// ResizeTo sets the ring buffer capacity to the given value, bypassing the growth and shrink
+// configuration. No objects are allocated when growing; use Prewarm for that.
+// When shrinking, idle objects that no longer fit are dropped.
+//
+// Returns ErrInvalidCapacity if capacity is not positive or smaller than the number of
+// objects in use, ErrHardLimitExceeded if it's above the hard limit, and an error if the
+// pool is closed.
+func (p *Pool[T]) ResizeTo(capacity int) error {
+	if capacity <= 0 {
+		return fmt.Errorf("%w: capacity must be greater than 0, got %d", ErrInvalidCapacity, capacity)
+	}
+
+	if capacity > p.config.hardLimit {
+		return fmt.Errorf("%w: capacity (%d) is above hard limit (%d)", ErrHardLimitExceeded, capacity, p.config.hardLimit)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ctx.Err() != nil {
+		return errPoolClosed
+	}
+
+	return p.resizeRingBuffer(capacity)
+}
+
+// ResizeL1To sets the L1 cache capacity to the given value, bypassing the fast path growth
+// and shrink configuration. Objects that no longer fit are moved to the ring buffer,
+// or dropped if the ring buffer is full.
+//
+// Returns ErrInvalidCapacity if capacity is not positive, ErrHardLimitExceeded if it's
+// above the hard limit, and an error if the pool is closed.
+func (p *Pool[T]) ResizeL1To(capacity int) error {
+	if capacity <= 0 {
+		return fmt.Errorf("%w: L1 capacity must be greater than 0, got %d", ErrInvalidCapacity, capacity)
+	}
+
+	if capacity > p.config.hardLimit {
+		return fmt.Errorf("%w: L1 capacity (%d) is above hard limit (%d)", ErrHardLimitExceeded, capacity, p.config.hardLimit)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ctx.Err() != nil {
+		return errPoolClosed
+	}
+
+	p.resizeL1(capacity)
+	return nil
+}
+
+// ShrinkNow runs a shrink cycle on demand, outside the shrink ticker. It applies the same
+// rules as the background shrink, including minCapacity and the objects currently in use.
+//
+// Returns ErrShrinkRejected if those rules prevented the ring buffer from shrinking,
+// and an error if the pool is closed.
+func (p *Pool[T]) ShrinkNow() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ctx.Err() != nil {
+		return errPoolClosed
+	}
+
+	before := p.stats.currentCapacity
+	p.shrinkExecution(p.config.clock.Now())
+
+	if p.stats.currentCapacity >= before {
+		return fmt.Errorf("%w: capacity remains at %d", ErrShrinkRejected, before)
+	}
+
+	return nil
+}
+
+// resizeRingBuffer replaces the ring buffer with one of the given capacity, keeping as many
+// idle objects as fit. Must be called with p.mu held.
+func (p *Pool[T]) resizeRingBuffer(newCapacity int) error {
+	currentCap := p.stats.currentCapacity
+	if newCapacity == currentCap {
+		return nil
+	}
+
+	if newCapacity > currentCap {
+		if err := p.chargeBudget(newCapacity); err != nil {
+			return err
+		}
+
+		newRingBuffer, err := p.createAndPopulateBuffer(newCapacity)
+		if err != nil {
+			p.lowerBudget(currentCap)
+			return fmt.Errorf("%w: %w", errRingBufferFailed, err)
+		}
+		p.pool = newRingBuffer
+	} else {
+		inUse := p.stats.inUse()
+		if !p.canShrink(newCapacity, inUse) {
+			return fmt.Errorf("%w: capacity (%d) is below objects in use (%d)", ErrInvalidCapacity, newCapacity, inUse)
+		}
+
+		newRingBuffer := p.createShrinkBuffer(newCapacity)
+		inL1 := p.trimL1ToFit(newCapacity, inUse)
+		itemsToKeep := p.calculateItemsToKeep(newCapacity, inUse+inL1)
+
+		destroyedCount := p.pool.Length(false) - itemsToKeep
+		if err := p.migrateItems(newRingBuffer, itemsToKeep); err != nil {
+			return fmt.Errorf("%w: %w", errRingBufferFailed, err)
+		}
+
+		if destroyedCount > 0 {
+			p.stats.objectsDestroyed += destroyedCount
+		}
+
+		p.forgetRingBufferObjects()
+		p.pool.Close()
+		p.pool = newRingBuffer
+		p.lowerBudget(newCapacity)
+	}
+
+	p.recordCapacityEvent(CapacityResize, currentCap, newCapacity)
+	p.stats.currentCapacity = newCapacity
+	p.isGrowthBlocked.Store(newCapacity >= p.config.hardLimit)
+	p.shrinkCond.Signal()
+
+	return nil
+}
+
+// resizeL1 replaces the L1 channel with one of the given capacity. Objects that don't fit
+// are moved to the ring buffer, or dropped if it has no room. Must be called with p.mu held.
+func (p *Pool[T]) resizeL1(newCapacity int) {
+	oldCh := *p.cacheL1
+	newCh := make(chan T, newCapacity)
+
+	close(oldCh)
+	p.cacheL1 = &newCh
+
+	for obj := range oldCh {
+		select {
+		case newCh <- obj:
+			continue
+		default:
+		}
+
+		if p.pool.Free() > 0 {
+			if err := p.pool.Write(obj); err == nil {
+				continue
+			}
+		}
+
+		p.tracker.forget(obj)
+		p.stats.objectsDestroyed++
+	}
+
+	p.stats.currentL1Capacity = newCapacity
+}
@@ -0,0 +1,361 @@
+package pool
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+)
+
+var errTrackingRequiresPointers = errors.New("object tracking requires a pointer type")
+
+// holdTimeBounds are the upper bounds of the hold time distribution, the last bucket is unbounded.
+var holdTimeBounds = []time.Duration{
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+	math.MaxInt64,
+}
+
+// reuseBounds are the upper bounds of the reuse distribution, the last bucket is unbounded.
+var reuseBounds = []int{0, 1, 10, 100, 1000, math.MaxInt}
+
+// ObjectMetadata describes the life of a tracked object.
+type ObjectMetadata struct {
+	CreatedAt time.Time
+
+	// ReuseCount is the number of times the object was handed out.
+	ReuseCount int
+
+	// TotalHoldTime is the time the object spent with callers, across completed checkouts.
+	TotalHoldTime time.Duration
+
+	// LastCheckout is when the object was last handed out, zero if it never was.
+	LastCheckout time.Time
+
+	// LastCaller is the file and line of the last caller of Get, empty unless caller capture is enabled.
+	LastCaller string
+
+	// held reports whether the object is currently with a caller.
+	held bool
+
+	// timedOut reports whether the current checkout exceeded the max hold time.
+	timedOut bool
+}
+
+// HoldTimeBucket counts the checkouts returned within UpTo, and above the previous bucket.
+type HoldTimeBucket struct {
+	UpTo  time.Duration
+	Count uint64
+}
+
+// ReuseBucket counts the tracked objects handed out at most UpTo times, and more than the previous bucket.
+type ReuseBucket struct {
+	UpTo  int
+	Count int
+}
+
+// ObjectUsageStats summarizes the metadata of the tracked objects.
+type ObjectUsageStats struct {
+	// TrackedObjects is the number of live objects being tracked.
+	TrackedObjects int
+
+	// RetiredObjects is the number of objects Put retired after reaching maxReuses.
+	RetiredObjects uint64
+
+	// HoldTimeouts is the number of checkouts that exceeded the max hold time, and
+	// ReclaimedObjects the number of them the pool stopped counting as in use.
+	HoldTimeouts     uint64
+	ReclaimedObjects uint64
+
+	// HoldTimes is the distribution of the hold time of every completed checkout.
+	HoldTimes []HoldTimeBucket
+
+	// Reuses is the distribution of the reuse count of the live objects.
+	Reuses []ReuseBucket
+}
+
+// objectTracker keeps the metadata of the objects of a pool, keyed by the objects themselves.
+// Its methods do nothing on a nil tracker, which is what pools without tracking have.
+type objectTracker struct {
+	mu sync.Mutex
+
+	clock         Clock
+	maxReuses     int
+	captureCaller bool
+
+	objects   map[any]*ObjectMetadata
+	holdTimes []uint64
+
+	// reclaimed holds the objects taken away from their holder after a hold timeout,
+	// until the holder returns them.
+	reclaimed    map[any]struct{}
+	holdTimeouts uint64
+}
+
+// checkinResult tells Put what to do with a returned object.
+type checkinResult int
+
+const (
+	// checkinStore stores the object back in the pool.
+	checkinStore checkinResult = iota
+
+	// checkinRetire drops the object, it reached maxReuses.
+	checkinRetire
+
+	// checkinDiscard drops the object, it was reclaimed and is no longer accounted for.
+	checkinDiscard
+)
+
+// heldObject is an object that exceeded the max hold time, and the caller holding it.
+type heldObject struct {
+	obj    any
+	holder string
+}
+
+func newObjectTracker(params *objectTrackingParameters, clock Clock) *objectTracker {
+	if !params.enabled {
+		return nil
+	}
+
+	return &objectTracker{
+		clock:         clock,
+		maxReuses:     params.maxReuses,
+		captureCaller: params.captureCaller,
+		objects:       make(map[any]*ObjectMetadata),
+		holdTimes:     make([]uint64, len(holdTimeBounds)),
+		reclaimed:     make(map[any]struct{}),
+	}
+}
+
+// track starts tracking a newly created object.
+func (t *objectTracker) track(obj any) {
+	if t == nil {
+		return
+	}
+
+	now := t.clock.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.objects[obj] = &ObjectMetadata{CreatedAt: now}
+}
+
+// forget stops tracking an object the pool dropped.
+func (t *objectTracker) forget(obj any) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.objects, obj)
+}
+
+// checkout records that obj was handed out. skip is the number of stack frames above
+// the caller of checkout to find the caller of Get.
+func (t *objectTracker) checkout(obj any, skip int) {
+	if t == nil {
+		return
+	}
+
+	var caller string
+	if t.captureCaller {
+		if _, file, line, ok := runtime.Caller(skip + 1); ok {
+			caller = fmt.Sprintf("%s:%d", file, line)
+		}
+	}
+
+	now := t.clock.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	meta, ok := t.objects[obj]
+	if !ok {
+		// Objects created before tracking could see them, e.g. put by the caller.
+		meta = &ObjectMetadata{CreatedAt: now}
+		t.objects[obj] = meta
+	}
+
+	meta.ReuseCount++
+	meta.LastCheckout = now
+	meta.held = true
+	meta.timedOut = false
+	if t.captureCaller {
+		meta.LastCaller = caller
+	}
+}
+
+// checkin records that obj was returned. Objects that reached maxReuses are no longer
+// tracked and must be retired, reclaimed ones must be discarded.
+func (t *objectTracker) checkin(obj any) checkinResult {
+	if t == nil {
+		return checkinStore
+	}
+
+	now := t.clock.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.reclaimed[obj]; ok {
+		delete(t.reclaimed, obj)
+		return checkinDiscard
+	}
+
+	meta, ok := t.objects[obj]
+	if !ok {
+		return checkinStore
+	}
+
+	if meta.held {
+		held := now.Sub(meta.LastCheckout)
+		meta.TotalHoldTime += held
+		meta.held = false
+		t.holdTimes[holdTimeBucket(held)]++
+	}
+
+	if t.maxReuses > 0 && meta.ReuseCount >= t.maxReuses {
+		delete(t.objects, obj)
+		return checkinRetire
+	}
+
+	return checkinStore
+}
+
+// expired returns the objects held longer than maxHold that weren't reported yet.
+// With reclaim, they stop being tracked until their holder returns them.
+func (t *objectTracker) expired(maxHold time.Duration, reclaim bool) []heldObject {
+	now := t.clock.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var expired []heldObject
+	for obj, meta := range t.objects {
+		if !meta.held || meta.timedOut || now.Sub(meta.LastCheckout) <= maxHold {
+			continue
+		}
+
+		meta.timedOut = true
+		t.holdTimeouts++
+		expired = append(expired, heldObject{obj: obj, holder: meta.LastCaller})
+
+		if reclaim {
+			delete(t.objects, obj)
+			t.reclaimed[obj] = struct{}{}
+		}
+	}
+
+	return expired
+}
+
+func (t *objectTracker) metadata(obj any) (ObjectMetadata, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	meta, ok := t.objects[obj]
+	if !ok {
+		return ObjectMetadata{}, false
+	}
+	return *meta, true
+}
+
+func (t *objectTracker) usage() ObjectUsageStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	usage := ObjectUsageStats{
+		TrackedObjects: len(t.objects),
+		HoldTimeouts:   t.holdTimeouts,
+		HoldTimes:      make([]HoldTimeBucket, len(holdTimeBounds)),
+		Reuses:         make([]ReuseBucket, len(reuseBounds)),
+	}
+
+	for i, bound := range holdTimeBounds {
+		usage.HoldTimes[i] = HoldTimeBucket{UpTo: bound, Count: t.holdTimes[i]}
+	}
+
+	for i, bound := range reuseBounds {
+		usage.Reuses[i].UpTo = bound
+	}
+	for _, meta := range t.objects {
+		usage.Reuses[reuseBucket(meta.ReuseCount)].Count++
+	}
+
+	return usage
+}
+
+func holdTimeBucket(held time.Duration) int {
+	for i, bound := range holdTimeBounds {
+		if held <= bound {
+			return i
+		}
+	}
+	return len(holdTimeBounds) - 1
+}
+
+func reuseBucket(reuses int) int {
+	for i, bound := range reuseBounds {
+		if reuses <= bound {
+			return i
+		}
+	}
+	return len(reuseBounds) - 1
+}
+
+// ObjectMetadata returns the metadata of obj, or false if object tracking is disabled
+// or the object isn't tracked by the pool.
+func (p *Pool[T]) ObjectMetadata(obj T) (ObjectMetadata, bool) {
+	if p.tracker == nil {
+		return ObjectMetadata{}, false
+	}
+	return p.tracker.metadata(obj)
+}
+
+// ObjectUsage returns the hold time and reuse distributions of the pool's objects, or false
+// if object tracking is disabled.
+func (p *Pool[T]) ObjectUsage() (ObjectUsageStats, bool) {
+	if p.tracker == nil {
+		return ObjectUsageStats{}, false
+	}
+	usage := p.tracker.usage()
+	usage.RetiredObjects = p.stats.retiredObjects.Load()
+	usage.ReclaimedObjects = p.stats.reclaimedObjects.Load()
+	return usage, true
+}
+
+// retire drops an object that reached maxReuses instead of storing it, freeing its slot
+// so the pool can allocate a replacement on demand.
+func (p *Pool[T]) retire() {
+	p.mu.Lock()
+	p.stats.objectsDestroyed++
+	p.mu.Unlock()
+
+	p.stats.retiredObjects.Add(1)
+}
+
+// forgetRingBufferObjects stops tracking the objects left in the ring buffer before
+// it's replaced and dropped. Must be called with p.mu held.
+func (p *Pool[T]) forgetRingBufferObjects() {
+	if p.tracker == nil {
+		return
+	}
+
+	part1, part2, err := p.pool.GetAllView()
+	if err != nil {
+		return
+	}
+
+	for _, obj := range part1 {
+		p.tracker.forget(obj)
+	}
+	for _, obj := range part2 {
+		p.tracker.forget(obj)
+	}
+}
@@ -139,7 +139,7 @@ func Benchmark_Shrink(b *testing.B) {
 			break
 		}
 
-		poolObj.performShrink(newCap, inUse)
+		poolObj.performShrink(newCap, inUse, time.Now())
 
 		newLen := poolObj.pool.Capacity()
 		if newLen >= prevCap {
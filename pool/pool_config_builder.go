@@ -28,6 +28,12 @@ func NewPoolConfigBuilder[T any]() PoolConfigBuilder[T] {
 	copiedGrowth := *defaultGrowthParameters
 	copiedFastPath := *defaultFastPath
 	copiedAllocationStrategy := *defaultAllocationStrategy
+	copiedBackgroundFill := *defaultBackgroundFill
+	copiedWaitQueue := *defaultWaitQueue
+	copiedAdaptiveFastPath := *defaultAdaptiveFastPath
+	copiedRetryPolicy := *defaultRetryPolicy
+	copiedObjectTracking := *defaultObjectTracking
+	copiedHoldTimeout := *defaultHoldTimeout
 
 	copiedFastPath.shrink = &shrinkParameters{
 		aggressivenessLevel: copiedShrink.aggressivenessLevel,
@@ -42,6 +48,13 @@ func NewPoolConfigBuilder[T any]() PoolConfigBuilder[T] {
 			fastPath:           &copiedFastPath,
 			ringBufferConfig:   &copiedRingBufferConfig,
 			allocationStrategy: &copiedAllocationStrategy,
+			backgroundFill:     &copiedBackgroundFill,
+			waitQueue:          &copiedWaitQueue,
+			adaptiveFastPath:   &copiedAdaptiveFastPath,
+			retryPolicy:        &copiedRetryPolicy,
+			objectTracking:     &copiedObjectTracking,
+			holdTimeout:        &copiedHoldTimeout,
+			clock:              defaultClock,
 		},
 	}
 
@@ -283,5 +296,33 @@ func (b *poolConfigBuilder[T]) Build() (*PoolConfig[T], error) {
 		return nil, fmt.Errorf("allocation strategy validation failed: %w", err)
 	}
 
+	if err := b.validateBackgroundFillConfig(); err != nil {
+		return nil, fmt.Errorf("background fill validation failed: %w", err)
+	}
+
+	if err := b.validateWaitQueueConfig(); err != nil {
+		return nil, fmt.Errorf("wait queue validation failed: %w", err)
+	}
+
+	if err := b.validateAdaptiveFastPathConfig(); err != nil {
+		return nil, fmt.Errorf("adaptive fast path validation failed: %w", err)
+	}
+
+	if err := b.validateRetryPolicy(); err != nil {
+		return nil, fmt.Errorf("retry policy validation failed: %w", err)
+	}
+
+	if err := b.validateObjectTrackingConfig(); err != nil {
+		return nil, fmt.Errorf("object tracking validation failed: %w", err)
+	}
+
+	if err := b.validateHoldTimeoutConfig(); err != nil {
+		return nil, fmt.Errorf("hold timeout validation failed: %w", err)
+	}
+
+	if err := b.validateInitialState(); err != nil {
+		return nil, fmt.Errorf("initial state validation failed: %w", err)
+	}
+
 	return b.config, nil
 }
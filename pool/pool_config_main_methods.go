@@ -293,3 +293,225 @@ func (b *poolConfigBuilder[T]) SetAllocationStrategy(allocPercent int, allocAmou
 
 	return b
 }
+
+// SetAllocationMode selects how many objects the pool allocates ahead of demand.
+// Parameters:
+//   - mode: AllocationPercent follows allocPercent and allocAmount, AllocationLazy creates
+//     objects one at a time as they're requested, AllocationEager fills the whole capacity
+//     at initialization and after every growth
+func (b *poolConfigBuilder[T]) SetAllocationMode(mode AllocationMode) PoolConfigBuilder[T] {
+	b.config.allocationStrategy.Mode = mode
+	return b
+}
+
+// SetAllocBatch replaces the allocator and the cloner whenever the pool creates objects:
+// at initialization, when growing, on demand, in Prewarm and in the background filler.
+// allocBatch is called once with the number of objects needed and returns them, e.g. as
+// pointers into a single backing array. Returning fewer objects is allowed, extra objects
+// are ignored, and returning none counts as an allocation failure.
+//
+// Note: A nil function is ignored, the allocator will be used instead.
+func (b *poolConfigBuilder[T]) SetAllocBatch(allocBatch func(n int) []T) PoolConfigBuilder[T] {
+	if allocBatch != nil {
+		b.config.allocBatch = allocBatch
+	}
+	return b
+}
+
+// SetAllocationFailureBackoff pauses allocation for backoff after failureThreshold
+// consecutive allocator failures. Only meaningful for pools created with
+// NewPoolWithFallibleAllocator.
+// Parameters:
+//   - failureThreshold: Number of consecutive failures before allocation is paused
+//   - backoff: How long allocation stays paused
+func (b *poolConfigBuilder[T]) SetAllocationFailureBackoff(failureThreshold int, backoff time.Duration) PoolConfigBuilder[T] {
+	b.config.allocationStrategy.FailureThreshold = failureThreshold
+	b.config.allocationStrategy.FailureBackoff = backoff
+	return b
+}
+
+// ============================================================================
+// Background Fill Configuration Methods
+// ============================================================================
+
+// SetBackgroundFillConfigs enables the background filler, which keeps lowWatermark ready
+// objects available by allocating outside the pool lock.
+// Parameters:
+//   - lowWatermark: Number of ready objects (L1 + ring buffer) to keep available
+//   - checkInterval: Time between checks, the filler is also woken up on L1 misses
+//
+// Note: A non-positive checkInterval is ignored, the default value will be used instead.
+func (b *poolConfigBuilder[T]) SetBackgroundFillConfigs(lowWatermark int, checkInterval time.Duration) PoolConfigBuilder[T] {
+	b.config.backgroundFill.enabled = true
+	b.config.backgroundFill.lowWatermark = lowWatermark
+
+	if checkInterval > 0 {
+		b.config.backgroundFill.checkInterval = checkInterval
+	}
+
+	return b
+}
+
+// ============================================================================
+// Wait Queue Configuration Methods
+// ============================================================================
+
+// SetWaitQueueConfigs makes Get wait in a fair queue when no object is available, instead of
+// blocking on the ring buffer, so callers are served in arrival order.
+// Parameters:
+//   - maxWaiters: Maximum number of queued callers, zero means no limit
+//
+// Note: Get waits in the queue whether the ring buffer is blocking or not, the queue replaces
+// the ring buffer blocking. The ring buffer read timeout still applies to Get.
+func (b *poolConfigBuilder[T]) SetWaitQueueConfigs(maxWaiters int) PoolConfigBuilder[T] {
+	b.config.waitQueue.enabled = true
+	b.config.waitQueue.maxWaiters = maxWaiters
+	return b
+}
+
+// ============================================================================
+// Adaptive Fast Path Configuration Methods
+// ============================================================================
+
+// SetAdaptiveFastPathConfigs makes the L1 cache capacity adapt to how often the fast path
+// misses, measured over a window, instead of following the ring buffer growth and shrink events.
+// Parameters:
+//   - minSize: Minimum L1 capacity
+//   - maxSize: Maximum L1 capacity
+//   - window: Time between evaluations, the miss rates are measured over it
+//   - growMissRate: Miss rate above which L1 grows, from 0 to 1
+//   - shrinkMissRate: Miss rate below which L1 shrinks, from 0 to 1
+//
+// The miss rate is the higher of the L2 spill rate of Put and the L1 miss rate of Get.
+// L1 grows with the fast path growth factors and shrinks by the fast path shrink percent.
+//
+// Note: Zero or negative values are ignored, default values will be used instead.
+// The fast path initial size must be within minSize and maxSize.
+func (b *poolConfigBuilder[T]) SetAdaptiveFastPathConfigs(minSize, maxSize int, window time.Duration, growMissRate, shrinkMissRate float64) PoolConfigBuilder[T] {
+	afp := b.config.adaptiveFastPath
+	afp.enabled = true
+
+	if minSize > 0 {
+		afp.minSize = minSize
+	}
+
+	if maxSize > 0 {
+		afp.maxSize = maxSize
+	}
+
+	if window > 0 {
+		afp.window = window
+	}
+
+	if growMissRate > 0 {
+		afp.growMissRate = growMissRate
+	}
+
+	if shrinkMissRate > 0 {
+		afp.shrinkMissRate = shrinkMissRate
+	}
+
+	return b
+}
+
+// ============================================================================
+// Retry Configuration Methods
+// ============================================================================
+
+// SetRetryPolicy configures how the slow paths of Get and Put retry failed ring buffer
+// operations. The default policy retries 4 times with a fixed 10ms delay.
+func (b *poolConfigBuilder[T]) SetRetryPolicy(policy RetryPolicy) PoolConfigBuilder[T] {
+	*b.config.retryPolicy = policy
+	return b
+}
+
+// ============================================================================
+// Object Tracking Configuration Methods
+// ============================================================================
+
+// SetObjectTrackingConfigs enables the per-object metadata: creation time, number of
+// checkouts, total hold time and last caller of Get.
+// Parameters:
+//   - maxReuses: Number of checkouts after which Put retires an object, zero means no limit
+//   - captureCaller: Record the file and line of the caller of Get on every checkout
+//
+// Note: Only pools of pointer types can track their objects.
+func (b *poolConfigBuilder[T]) SetObjectTrackingConfigs(maxReuses int, captureCaller bool) PoolConfigBuilder[T] {
+	b.config.objectTracking.enabled = true
+	b.config.objectTracking.maxReuses = maxReuses
+	b.config.objectTracking.captureCaller = captureCaller
+	return b
+}
+
+// SetHoldTimeoutConfigs limits how long a caller may hold an object, checking every
+// checkInterval for objects held longer than maxHoldTime.
+// Parameters:
+//   - maxHoldTime: Longest a single checkout may last
+//   - checkInterval: Time between checks, bounds how late a timeout is detected
+//   - reclaim: Stop counting timed out objects as in use and allocate replacements
+//   - onTimeout: Called once per timed out checkout with the object and the caller of Get
+//     holding it, which is empty unless caller capture is enabled. May be nil
+//
+// Reclaimed objects returned later are dropped by Put. Enables object tracking.
+//
+// Note: A non-positive checkInterval is ignored, the default value will be used instead.
+func (b *poolConfigBuilder[T]) SetHoldTimeoutConfigs(maxHoldTime, checkInterval time.Duration, reclaim bool, onTimeout func(obj T, holder string)) PoolConfigBuilder[T] {
+	b.config.objectTracking.enabled = true
+
+	b.config.holdTimeout.enabled = true
+	b.config.holdTimeout.maxHoldTime = maxHoldTime
+	b.config.holdTimeout.reclaim = reclaim
+	b.config.onHoldTimeout = onTimeout
+
+	if checkInterval > 0 {
+		b.config.holdTimeout.checkInterval = checkInterval
+	}
+
+	return b
+}
+
+// ============================================================================
+// Initial State Configuration Methods
+// ============================================================================
+
+// SetInitialState makes the pool start with the ring buffer and L1 capacities and the growth
+// and shrink counters of state, usually exported by ExportState before a restart and stored
+// as JSON. The initial objects are preallocated according to the restored capacity.
+// Capacities are clamped to the hard limit, and the ring buffer capacity to the minimum shrink
+// capacity, so a state exported under a different configuration stays usable.
+//
+// Note: Zero capacities are ignored, the configured initial sizes will be used instead.
+func (b *poolConfigBuilder[T]) SetInitialState(state PoolState) PoolConfigBuilder[T] {
+	b.config.initialState = &state
+	return b
+}
+
+// ============================================================================
+// Handle Configuration Methods
+// ============================================================================
+
+// SetHandleDebugConfigs makes Handle.Release pass objects to poison and drop them instead
+// of returning them to the pool. Poison should leave the object in a state its users are
+// bound to notice, e.g. a closed flag or a nil buffer, so any use after Release shows up
+// in tests. Dropped objects free their slot, the pool allocates replacements on demand.
+//
+// Note: A nil poison function disables the debug mode.
+func (b *poolConfigBuilder[T]) SetHandleDebugConfigs(poison func(obj T)) PoolConfigBuilder[T] {
+	b.config.handlePoison = poison
+	return b
+}
+
+// ============================================================================
+// Clock Configuration Methods
+// ============================================================================
+
+// SetClock replaces the system clock used by the shrink, background fill and allocation
+// backoff logic, mainly so tests can control time.
+//
+// Note: A nil clock is ignored, the system clock will be used instead.
+func (b *poolConfigBuilder[T]) SetClock(clock Clock) PoolConfigBuilder[T] {
+	if clock != nil {
+		b.config.clock = clock
+	}
+	return b
+}
@@ -0,0 +1,79 @@
+package pool
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// retryWithPolicy runs op until it succeeds or the retry policy gives up, returning the last error.
+// Every retry is counted in the pool statistics, and the wait between retries, measured on the
+// pool clock, is cut short when the pool is closed.
+func (p *Pool[T]) retryWithPolicy(op func() error) error {
+	policy := p.config.retryPolicy
+	start := p.config.clock.Now()
+
+	for attempt := 0; ; attempt++ {
+		err := op()
+		if err == nil {
+			return nil
+		}
+
+		if policy.Mode == RetryNone || attempt >= policy.MaxRetries {
+			return err
+		}
+
+		delay := policy.delay(attempt)
+		if policy.MaxElapsed > 0 && p.config.clock.Now().Sub(start)+delay > policy.MaxElapsed {
+			return err
+		}
+
+		p.stats.totalRetries.Add(1)
+
+		if !p.sleepUnlessClosed(delay) {
+			return err
+		}
+	}
+}
+
+// sleepUnlessClosed waits for d on the pool clock, returning false if the pool was closed meanwhile.
+func (p *Pool[T]) sleepUnlessClosed(d time.Duration) bool {
+	if d <= 0 {
+		return p.ctx.Err() == nil
+	}
+
+	timer := p.config.clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return true
+	case <-p.ctx.Done():
+		return false
+	}
+}
+
+// delay returns how long to wait before the retry following the given attempt, starting at zero.
+func (r *RetryPolicy) delay(attempt int) time.Duration {
+	d := r.InitialDelay
+
+	if r.Mode == RetryExponential {
+		for range attempt {
+			d *= 2
+			if r.MaxDelay > 0 && d >= r.MaxDelay {
+				d = r.MaxDelay
+				break
+			}
+		}
+	}
+
+	if r.Jitter > 0 {
+		spread := float64(d) * r.Jitter
+		d += time.Duration(spread * (2*rand.Float64() - 1))
+	}
+
+	if r.MaxDelay > 0 && d > r.MaxDelay {
+		d = r.MaxDelay
+	}
+
+	return max(d, 0)
+}
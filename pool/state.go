@@ -0,0 +1,67 @@
+package pool
+
+// PoolState is the capacity a pool learned from its traffic, exported with ExportState and
+// restored by SetInitialState so a restarted pool skips the growth it went through.
+// It's meant to be serialized as JSON.
+type PoolState struct {
+	// Capacity and L1Capacity are the ring buffer and L1 cache capacities.
+	Capacity   int `json:"capacity"`
+	L1Capacity int `json:"l1Capacity"`
+
+	TotalGrowthEvents int `json:"totalGrowthEvents"`
+	TotalShrinkEvents int `json:"totalShrinkEvents"`
+
+	// LastL1ResizeAtGrowthNum and LastResizeAtShrinkNum are the growth and shrink events at
+	// the last L1 resizes, so the restored pool resizes L1 on the same schedule.
+	LastL1ResizeAtGrowthNum int `json:"lastL1ResizeAtGrowthNum"`
+	LastResizeAtShrinkNum   int `json:"lastResizeAtShrinkNum"`
+}
+
+// ExportState returns the current capacities and growth and shrink counters of the pool.
+func (p *Pool[T]) ExportState() PoolState {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return PoolState{
+		Capacity:                p.stats.currentCapacity,
+		L1Capacity:              p.stats.currentL1Capacity,
+		TotalGrowthEvents:       p.stats.totalGrowthEvents,
+		TotalShrinkEvents:       p.stats.totalShrinkEvents,
+		LastL1ResizeAtGrowthNum: p.stats.lastL1ResizeAtGrowthNum,
+		LastResizeAtShrinkNum:   p.stats.lastResizeAtShrinkNum,
+	}
+}
+
+// restoredCapacities returns the ring buffer and L1 capacities a new pool starts with: the
+// initial state's, kept within the hard limit and, for the ring buffer, above the minimum
+// shrink capacity, or the configured initial sizes.
+func (c *PoolConfig[T]) restoredCapacities() (capacity, l1Capacity int) {
+	capacity, l1Capacity = c.initialCapacity, c.fastPath.initialSize
+
+	state := c.initialState
+	if state == nil {
+		return capacity, l1Capacity
+	}
+
+	if state.Capacity > 0 {
+		capacity = min(max(state.Capacity, c.shrink.minCapacity), c.hardLimit)
+	}
+
+	if state.L1Capacity > 0 {
+		l1Capacity = min(state.L1Capacity, c.hardLimit)
+	}
+
+	return capacity, l1Capacity
+}
+
+// restoreCounters sets the growth and shrink counters of the initial state, if any.
+func (s *poolStats) restoreCounters(state *PoolState) {
+	if state == nil {
+		return
+	}
+
+	s.totalGrowthEvents = state.TotalGrowthEvents
+	s.totalShrinkEvents = state.TotalShrinkEvents
+	s.lastL1ResizeAtGrowthNum = state.LastL1ResizeAtGrowthNum
+	s.lastResizeAtShrinkNum = state.LastResizeAtShrinkNum
+}
@@ -2,15 +2,14 @@ package pool
 
 import (
 	"fmt"
-	"sync"
 	"sync/atomic"
 	"time"
 )
 
-// poolStats contains all the statistics (essential and non-essential) for the pool
+// poolStats contains all the statistics (essential and non-essential) for the pool.
+// The atomic fields are updated on the fast path without locking, every other field
+// is guarded by the pool's mu.
 type poolStats struct {
-	mu sync.RWMutex
-
 	objectsCreated   int
 	objectsDestroyed int
 	initialCapacity  int
@@ -23,6 +22,25 @@ type poolStats struct {
 	FastReturnHit  atomic.Uint64
 	FastReturnMiss atomic.Uint64
 
+	// totalRetries counts the retries of the slow paths of Get and Put.
+	totalRetries atomic.Uint64
+
+	// l1GetMisses counts the Get calls that found the L1 cache empty.
+	l1GetMisses atomic.Uint64
+
+	// objectsInUse tracks gets minus returns in a single counter, so snapshots read it
+	// consistently while the fast path keeps updating the individual counters.
+	// It's incremented after an object leaves the pool and decremented before it's stored
+	// back, so it never counts an object that another caller can already get.
+	objectsInUse atomic.Int64
+
+	// retiredObjects counts the objects Put retired instead of storing, reclaimedObjects
+	// the ones taken away from their holder after a hold timeout, see objectTracker, and
+	// poisonedObjects the ones released through a Handle in debug mode.
+	retiredObjects   atomic.Uint64
+	reclaimedObjects atomic.Uint64
+	poisonedObjects  atomic.Uint64
+
 	totalShrinkEvents  int
 	consecutiveShrinks int
 
@@ -31,6 +49,22 @@ type poolStats struct {
 	lastL1ResizeAtGrowthNum int
 	lastResizeAtShrinkNum   int
 	currentL1Capacity       int
+
+	// recentEvents holds the last changes of currentCapacity, see recordCapacityEvent.
+	recentEvents []CapacityEvent
+}
+
+// recordGet counts an object handed out by the pool, once it's no longer stored in it.
+func (s *poolStats) recordGet() {
+	s.totalGets.Add(1)
+	s.objectsInUse.Add(1)
+}
+
+// inUse returns the number of objects that aren't stored in the pool, for resizing. Unlike
+// objectsInUse, it still counts an object while Put is storing it, so resizes leave room for it.
+func (s *poolStats) inUse() int {
+	returns := s.FastReturnHit.Load() + s.FastReturnMiss.Load() + s.retiredObjects.Load() + s.reclaimedObjects.Load() + s.poisonedObjects.Load()
+	return int(s.totalGets.Load() - returns)
 }
 
 // PoolStatsSnapshot represents a snapshot of the pool's statistics at a given moment
@@ -38,6 +72,7 @@ type PoolStatsSnapshot struct {
 	// Basic Pool Stats
 	InitialCapacity   int
 	CurrentCapacity   int
+	HardLimit         int
 	ObjectsInUse      uint64
 	TotalGets         uint64
 	TotalGrowthEvents int
@@ -48,6 +83,9 @@ type PoolStatsSnapshot struct {
 	FastReturnHit  uint64
 	FastReturnMiss uint64
 
+	// Retry Stats
+	TotalRetries uint64
+
 	// Shrink Stats
 	TotalShrinkEvents  int
 	ConsecutiveShrinks int
@@ -57,6 +95,7 @@ type PoolStatsSnapshot struct {
 	LastL1ResizeAtGrowthNum int
 	LastResizeAtShrinkNum   int
 	CurrentL1Capacity       int
+	L1GetMisses             uint64
 
 	// Derived Stats (computed from other fields)
 	AvailableObjects int
@@ -86,14 +125,44 @@ func (p *Pool[T]) PrintPoolStats() {
 	fmt.Printf("L1 cache length: %d\n", stats.L1Length)
 	fmt.Printf("Fast return hit: %d\n", stats.FastReturnHit)
 	fmt.Printf("Fast return miss: %d\n", stats.FastReturnMiss)
+	fmt.Printf("Total retries: %d\n", stats.TotalRetries)
+	fmt.Printf("L1 get misses: %d\n", stats.L1GetMisses)
 	fmt.Printf("L2 spill rate: %.2f%%\n", stats.L2SpillRate*100)
-	fmt.Printf("Utilization: %.2f%%\n", stats.Utilization)
+	fmt.Printf("Utilization: %.2f%%\n", stats.Utilization*100)
 	fmt.Printf("Last shrink time: %v\n", stats.LastShrinkTime)
+
+	if usage, ok := p.ObjectUsage(); ok {
+		fmt.Printf("Tracked objects: %d\n", usage.TrackedObjects)
+		fmt.Printf("Retired objects: %d\n", usage.RetiredObjects)
+		for i, bucket := range usage.HoldTimes {
+			if i == len(usage.HoldTimes)-1 {
+				fmt.Printf("Held over %v: %d\n", usage.HoldTimes[i-1].UpTo, bucket.Count)
+				continue
+			}
+			fmt.Printf("Held up to %v: %d\n", bucket.UpTo, bucket.Count)
+		}
+		for i, bucket := range usage.Reuses {
+			if i == len(usage.Reuses)-1 {
+				fmt.Printf("Reused over %d times: %d\n", usage.Reuses[i-1].UpTo, bucket.Count)
+				continue
+			}
+			fmt.Printf("Reused up to %d times: %d\n", bucket.UpTo, bucket.Count)
+		}
+	}
 	fmt.Println("===================")
 }
 
-// GetPoolStatsSnapshot returns a snapshot of the current pool statistics
+// GetPoolStatsSnapshot returns a point-in-time snapshot of the current pool statistics.
+// The fields guarded by p.mu are read under its read lock, so resizes never show half applied.
+// Get and Put keep updating the atomic counters, so ObjectsInUse comes from its own counter
+// rather than from TotalGets minus the returns, and returns are read before gets so they
+// never exceed them.
 func (p *Pool[T]) GetPoolStatsSnapshot() *PoolStatsSnapshot {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	objectsInUse := uint64(max(p.stats.objectsInUse.Load(), 0))
+
 	fastReturnHit := p.stats.FastReturnHit.Load()
 	fastReturnMiss := p.stats.FastReturnMiss.Load()
 	totalReturns := fastReturnHit + fastReturnMiss
@@ -103,14 +172,12 @@ func (p *Pool[T]) GetPoolStatsSnapshot() *PoolStatsSnapshot {
 		l2SpillRate = float64(fastReturnMiss) / float64(totalReturns)
 	}
 
+	totalGets := p.stats.totalGets.Load()
+
 	chPtr := p.cacheL1
 	ch := *chPtr
 	l1Len := len(ch)
 
-	totalPuts := p.stats.FastReturnHit.Load() + p.stats.FastReturnMiss.Load()
-	totalGets := p.stats.totalGets.Load()
-	objectsInUse := totalGets - totalPuts
-
 	objectsCreated := p.stats.objectsCreated
 	objectsDestroyed := p.stats.objectsDestroyed
 
@@ -118,6 +185,7 @@ func (p *Pool[T]) GetPoolStatsSnapshot() *PoolStatsSnapshot {
 		// Basic Pool Stats
 		InitialCapacity:   p.stats.initialCapacity,
 		CurrentCapacity:   p.stats.currentCapacity,
+		HardLimit:         p.config.hardLimit,
 		ObjectsInUse:      objectsInUse,
 		TotalGets:         totalGets,
 		TotalGrowthEvents: p.stats.totalGrowthEvents,
@@ -128,6 +196,9 @@ func (p *Pool[T]) GetPoolStatsSnapshot() *PoolStatsSnapshot {
 		FastReturnHit:  fastReturnHit,
 		FastReturnMiss: fastReturnMiss,
 
+		// Retry Stats
+		TotalRetries: p.stats.totalRetries.Load(),
+
 		// Shrink Stats
 		TotalShrinkEvents:  p.stats.totalShrinkEvents,
 		ConsecutiveShrinks: p.stats.consecutiveShrinks,
@@ -137,6 +208,7 @@ func (p *Pool[T]) GetPoolStatsSnapshot() *PoolStatsSnapshot {
 		LastL1ResizeAtGrowthNum: p.stats.lastL1ResizeAtGrowthNum,
 		LastResizeAtShrinkNum:   p.stats.lastResizeAtShrinkNum,
 		CurrentL1Capacity:       p.stats.currentL1Capacity,
+		L1GetMisses:             p.stats.l1GetMisses.Load(),
 
 		// Derived Stats (computed from other fields)
 		AvailableObjects: p.stats.currentCapacity - int(objectsInUse),
@@ -167,3 +239,48 @@ func (s *PoolStatsSnapshot) Validate(reqNum int) error {
 
 	return nil
 }
+
+// mergeSnapshots combines the snapshots of several pools into one, summing counters and
+// capacities and recomputing the derived rates. Resize bookkeeping fields are per pool
+// and are left at zero.
+func mergeSnapshots(snapshots []*PoolStatsSnapshot) *PoolStatsSnapshot {
+	merged := &PoolStatsSnapshot{}
+	for _, s := range snapshots {
+		merged.InitialCapacity += s.InitialCapacity
+		merged.CurrentCapacity += s.CurrentCapacity
+		merged.HardLimit += s.HardLimit
+		merged.ObjectsInUse += s.ObjectsInUse
+		merged.TotalGets += s.TotalGets
+		merged.TotalGrowthEvents += s.TotalGrowthEvents
+		merged.ObjectsCreated += s.ObjectsCreated
+		merged.ObjectsDestroyed += s.ObjectsDestroyed
+
+		merged.FastReturnHit += s.FastReturnHit
+		merged.FastReturnMiss += s.FastReturnMiss
+		merged.TotalRetries += s.TotalRetries
+
+		merged.TotalShrinkEvents += s.TotalShrinkEvents
+		merged.ConsecutiveShrinks += s.ConsecutiveShrinks
+		if s.LastShrinkTime.After(merged.LastShrinkTime) {
+			merged.LastShrinkTime = s.LastShrinkTime
+		}
+
+		merged.CurrentL1Capacity += s.CurrentL1Capacity
+		merged.L1GetMisses += s.L1GetMisses
+
+		merged.AvailableObjects += s.AvailableObjects
+		merged.RingBufferLength += s.RingBufferLength
+		merged.L1Length += s.L1Length
+	}
+
+	totalReturns := merged.FastReturnHit + merged.FastReturnMiss
+	if totalReturns > 0 {
+		merged.L2SpillRate = float64(merged.FastReturnMiss) / float64(totalReturns)
+	}
+
+	if merged.CurrentCapacity > 0 {
+		merged.Utilization = float64(merged.ObjectsInUse) / float64(merged.CurrentCapacity)
+	}
+
+	return merged
+}
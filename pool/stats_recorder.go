@@ -0,0 +1,220 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StatsSource is implemented by the types that report pool statistics, *Pool and *KeyedPool.
+type StatsSource interface {
+	GetPoolStatsSnapshot() *PoolStatsSnapshot
+}
+
+// StatsRates are the rates of a pool between two samples.
+type StatsRates struct {
+	// Interval is the time between the two samples.
+	Interval time.Duration
+
+	GetsPerSecond float64
+
+	// SpillRate is the fraction of the returns within the interval that missed the fast path
+	// and spilled to the ring buffer.
+	SpillRate float64
+
+	GrowthEventsPerMinute float64
+	ShrinkEventsPerMinute float64
+}
+
+// StatsSample is a snapshot taken by a StatsRecorder, with the rates since the previous sample.
+type StatsSample struct {
+	Time     time.Time
+	Snapshot PoolStatsSnapshot
+
+	// Rates is zero for the first sample, which has nothing to compare against.
+	Rates StatsRates
+}
+
+// StatsRecorder samples the statistics of a pool on an interval and keeps the most recent
+// samples in a bounded history, turning the cumulative counters into per-interval rates.
+type StatsRecorder struct {
+	source   StatsSource
+	clock    Clock
+	interval time.Duration
+
+	mu sync.RWMutex
+
+	// samples is a ring of the history, next is the slot of the following sample and
+	// count the number of slots in use.
+	samples []StatsSample
+	next    int
+	count   int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewStatsRecorder starts sampling source every interval, keeping the last historySize samples.
+// A nil clock uses the system clock. Call Stop to end the sampling.
+func NewStatsRecorder(source StatsSource, interval time.Duration, historySize int, clock Clock) (*StatsRecorder, error) {
+	if source == nil {
+		return nil, fmt.Errorf("stats source must not be nil")
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("sampling interval must be greater than 0, got %v", interval)
+	}
+
+	if historySize <= 0 {
+		return nil, fmt.Errorf("%w: history size must be greater than 0, got %d", ErrInvalidCapacity, historySize)
+	}
+
+	if clock == nil {
+		clock = defaultClock
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &StatsRecorder{
+		source:   source,
+		clock:    clock,
+		interval: interval,
+		samples:  make([]StatsSample, historySize),
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	go r.run()
+	return r, nil
+}
+
+// run records a sample on every tick until the recorder is stopped.
+func (r *StatsRecorder) run() {
+	defer close(r.done)
+
+	ticker := r.clock.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C():
+			r.Record()
+		}
+	}
+}
+
+// Stop ends the sampling and waits for the sampling goroutine to exit.
+// The recorded history stays available.
+func (r *StatsRecorder) Stop() {
+	r.cancel()
+	<-r.done
+}
+
+// Record takes a sample right away, in addition to the ones taken on the interval.
+func (r *StatsRecorder) Record() StatsSample {
+	snapshot := r.source.GetPoolStatsSnapshot()
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sample := StatsSample{Time: now, Snapshot: *snapshot}
+	if r.count > 0 {
+		sample.Rates = ratesBetween(r.latestLocked(), sample)
+	}
+
+	r.samples[r.next] = sample
+	r.next = (r.next + 1) % len(r.samples)
+	r.count = min(r.count+1, len(r.samples))
+
+	return sample
+}
+
+// Latest returns the most recent sample, or false if none was taken yet.
+func (r *StatsRecorder) Latest() (StatsSample, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.count == 0 {
+		return StatsSample{}, false
+	}
+	return r.latestLocked(), true
+}
+
+// Last returns up to the n most recent samples, from oldest to newest.
+func (r *StatsRecorder) Last(n int) []StatsSample {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n = max(min(n, r.count), 0)
+	samples := make([]StatsSample, n)
+
+	start := r.next - n + len(r.samples)
+	for i := range samples {
+		samples[i] = r.samples[(start+i)%len(r.samples)]
+	}
+
+	return samples
+}
+
+// Rates returns the rates across the n most recent samples, so they are averaged over
+// n-1 intervals. Returns false if fewer than two samples are available.
+func (r *StatsRecorder) Rates(n int) (StatsRates, bool) {
+	samples := r.Last(n)
+	if len(samples) < 2 {
+		return StatsRates{}, false
+	}
+
+	return ratesBetween(samples[0], samples[len(samples)-1]), true
+}
+
+// Len returns the number of samples in the history.
+func (r *StatsRecorder) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.count
+}
+
+// latestLocked returns the most recent sample. The caller must hold r.mu and have checked r.count.
+func (r *StatsRecorder) latestLocked() StatsSample {
+	return r.samples[(r.next-1+len(r.samples))%len(r.samples)]
+}
+
+// ratesBetween computes the rates from the counter deltas between from and to.
+// Counters that went backwards, e.g. after a keyed pool evicted a key, count as no change.
+func ratesBetween(from, to StatsSample) StatsRates {
+	rates := StatsRates{Interval: to.Time.Sub(from.Time)}
+	if rates.Interval <= 0 {
+		return rates
+	}
+
+	gets := counterDelta(from.Snapshot.TotalGets, to.Snapshot.TotalGets)
+	returnHits := counterDelta(from.Snapshot.FastReturnHit, to.Snapshot.FastReturnHit)
+	returnMisses := counterDelta(from.Snapshot.FastReturnMiss, to.Snapshot.FastReturnMiss)
+	growthEvents := max(to.Snapshot.TotalGrowthEvents-from.Snapshot.TotalGrowthEvents, 0)
+	shrinkEvents := max(to.Snapshot.TotalShrinkEvents-from.Snapshot.TotalShrinkEvents, 0)
+
+	seconds := rates.Interval.Seconds()
+	minutes := rates.Interval.Minutes()
+
+	rates.GetsPerSecond = float64(gets) / seconds
+	rates.GrowthEventsPerMinute = float64(growthEvents) / minutes
+	rates.ShrinkEventsPerMinute = float64(shrinkEvents) / minutes
+
+	if returns := returnHits + returnMisses; returns > 0 {
+		rates.SpillRate = float64(returnMisses) / float64(returns)
+	}
+
+	return rates
+}
+
+func counterDelta(from, to uint64) uint64 {
+	if to < from {
+		return 0
+	}
+	return to - from
+}
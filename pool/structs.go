@@ -22,7 +22,7 @@ import (
 // - Optional object cleanup
 // - Detailed statistics tracking
 //
-// Type parameter T must be a pointer type.
+// Type parameter T must be a pointer type, unless the pool is created with NewValuePool.
 type Pool[T any] struct {
 	// This provides fast access to frequently used objects without main pool contention.
 	cacheL1 *chan T
@@ -50,19 +50,53 @@ type Pool[T any] struct {
 	// config holds all pool configuration parameters
 	config *PoolConfig[T]
 
-	// Clean up objects when they're returned to the pool
-	cleaner func(T)
+	// Clean up objects when they're returned to the pool, the returned object is the one stored.
+	// Cleaners that work in place are wrapped by NewPool.
+	cleaner func(T) T
 
-	// Create new objects when the pool needs to grow
-	allocator func() T
+	// Create new objects when the pool needs to grow, allocators that can't fail are wrapped by NewPool
+	allocator func(context.Context) (T, error)
+
+	// allocFailures counts consecutive allocator failures for the failure backoff
+	allocFailures atomic.Int64
+
+	// allocBackoffUntil holds the unix nano time until which allocation is paused, zero if it's not
+	allocBackoffUntil atomic.Int64
 
 	// cloneTemplate creates a shallow copy of the object provided by the allocator, any reference types will be shared,
 	// delaying the initialization of the object state. (which you will be responsible for in case of reference types)
 	cloneTemplate func(T) T
 
-	// template is a template object that is used to create new objects
+	// template is a copy of the first object, cloned to create new objects when a cloner was provided
 	template T
 
+	// firstObjectPending is set while the first object hasn't been allocated yet, which only
+	// happens in lazy mode. The first allocation then runs validateObject and creates the template,
+	// serialized by firstObjectMu.
+	firstObjectPending atomic.Bool
+	firstObjectMu      sync.Mutex
+	validateObject     func(T) error
+
+	// fillSignal wakes up the background filler before its next check, if enabled.
+	fillSignal chan struct{}
+
+	// waiters holds the callers waiting for an object, served before the L1 cache on Put.
+	waiters *waitQueue[T]
+
+	// budget is the shared memory budget the pool's capacity is charged to, nil if it hasn't joined one.
+	budget *Budget
+
+	// sizeOf estimates the size in bytes of an object for the budget, nil until the pool joins one.
+	sizeOf atomic.Pointer[func(T) int]
+
+	// sizeTotal and sizeSamples sum the estimated sizes of the objects stored when the pool joined
+	// its budget and of those created since. Their mean is charged for every slot of the ring buffer capacity.
+	sizeTotal   atomic.Int64
+	sizeSamples atomic.Int64
+
+	// tracker holds the per-object metadata, nil if object tracking is disabled.
+	tracker *objectTracker
+
 	// ctx and cancel manage the pool's lifecycle
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -100,6 +134,43 @@ type PoolConfig[T any] struct {
 
 	// allocationStrategy configures how the pool allocates objects.
 	allocationStrategy *AllocationStrategy
+
+	// backgroundFill configures the optional goroutine that allocates objects ahead of demand.
+	backgroundFill *backgroundFillParameters
+
+	// waitQueue configures the queue callers wait in when no object is available.
+	waitQueue *waitQueueParameters
+
+	// adaptiveFastPath configures the optional mode that sizes the L1 cache from its miss rates.
+	adaptiveFastPath *adaptiveFastPathParameters
+
+	// retryPolicy configures the retries of the slow paths of Get and Put.
+	retryPolicy *RetryPolicy
+
+	// objectTracking configures the optional per-object metadata and reuse limit.
+	objectTracking *objectTrackingParameters
+
+	// holdTimeout configures the optional limit on how long a caller may hold an object.
+	holdTimeout *holdTimeoutParameters
+
+	// onHoldTimeout is called with the objects held past holdTimeout.maxHoldTime, may be nil.
+	onHoldTimeout func(obj T, holder string)
+
+	// allocBatch allocates objects in bulk in place of the allocator, may be nil.
+	allocBatch func(n int) []T
+
+	// initialState is the state a new pool starts from, nil to start from the initial sizes.
+	initialState *PoolState
+
+	// handlePoison enables the handle debug mode when set, see SetHandleDebugConfigs.
+	handlePoison func(obj T)
+
+	// clock is the source of time for the shrink, background fill and allocation backoff logic.
+	clock Clock
+
+	// objectBudget is a budget counting every object as one unit, joined at construction before
+	// any object is allocated. It's set by NewKeyedPool to share its global hard limit, nil otherwise.
+	objectBudget *Budget
 }
 
 // Getter methods for PoolConfig
@@ -127,6 +198,50 @@ func (c *PoolConfig[T]) GetRingBufferConfig() *config.RingBufferConfig[T] {
 	return c.ringBufferConfig
 }
 
+func (c *PoolConfig[T]) GetBackgroundFill() *backgroundFillParameters {
+	return c.backgroundFill
+}
+
+func (c *PoolConfig[T]) GetWaitQueue() *waitQueueParameters {
+	return c.waitQueue
+}
+
+func (c *PoolConfig[T]) GetAdaptiveFastPath() *adaptiveFastPathParameters {
+	return c.adaptiveFastPath
+}
+
+func (c *PoolConfig[T]) GetRetryPolicy() *RetryPolicy {
+	return c.retryPolicy
+}
+
+func (c *PoolConfig[T]) GetObjectTracking() *objectTrackingParameters {
+	return c.objectTracking
+}
+
+func (c *PoolConfig[T]) GetHoldTimeout() *holdTimeoutParameters {
+	return c.holdTimeout
+}
+
+func (c *PoolConfig[T]) GetOnHoldTimeout() func(obj T, holder string) {
+	return c.onHoldTimeout
+}
+
+func (c *PoolConfig[T]) GetAllocBatch() func(n int) []T {
+	return c.allocBatch
+}
+
+func (c *PoolConfig[T]) GetInitialState() *PoolState {
+	return c.initialState
+}
+
+func (c *PoolConfig[T]) GetHandlePoison() func(obj T) {
+	return c.handlePoison
+}
+
+func (c *PoolConfig[T]) GetClock() Clock {
+	return c.clock
+}
+
 // growthParameters controls how the pool expands to meet demand.
 // It supports both exponential and fixed growth strategies to balance
 // between rapid growth for high demand and controlled growth for stability.
@@ -318,6 +433,161 @@ func (f *fastPathParameters) GetPreReadBlockHookAttempts() int {
 	return f.preReadBlockHookAttempts
 }
 
+// backgroundFillParameters controls the background goroutine that keeps a number of
+// ready objects available ahead of demand, so expensive allocators don't run on the Get path.
+type backgroundFillParameters struct {
+	// enabled starts the background filler when the pool is created.
+	enabled bool
+
+	// lowWatermark is the number of ready objects (L1 + ring buffer) the filler tries to keep.
+	// Allocation is bounded by the ring buffer capacity and the hard limit.
+	lowWatermark int
+
+	// checkInterval determines how often the filler checks the number of ready objects.
+	// The filler is also woken up early when a Get misses the L1 cache.
+	checkInterval time.Duration
+}
+
+func (b *backgroundFillParameters) IsEnabled() bool {
+	return b.enabled
+}
+
+func (b *backgroundFillParameters) GetLowWatermark() int {
+	return b.lowWatermark
+}
+
+func (b *backgroundFillParameters) GetCheckInterval() time.Duration {
+	return b.checkInterval
+}
+
+// waitQueueParameters controls the queue callers wait in when the pool has no object available.
+// Waiters are served by priority, and in arrival order within the same priority.
+type waitQueueParameters struct {
+	// enabled makes Get wait in the queue instead of blocking on the ring buffer.
+	// GetWithPriority always uses the queue.
+	enabled bool
+
+	// maxWaiters is the maximum number of queued callers, zero means no limit.
+	// Callers beyond it fail immediately with ErrTooManyWaiters.
+	maxWaiters int
+}
+
+func (w *waitQueueParameters) IsEnabled() bool {
+	return w.enabled
+}
+
+func (w *waitQueueParameters) GetMaxWaiters() int {
+	return w.maxWaiters
+}
+
+// adaptiveFastPathParameters controls the optional mode in which the L1 cache capacity follows
+// how often the fast path misses, instead of following the resizes of the ring buffer.
+// Every window the pool measures the L2 spill rate of Put and the L1 miss rate of Get,
+// grows the L1 cache when the higher of the two is above growMissRate and shrinks it when
+// it's below shrinkMissRate.
+type adaptiveFastPathParameters struct {
+	// enabled starts the adaptive sizing goroutine and disables the growth and shrink
+	// event triggers of the L1 cache.
+	enabled bool
+
+	// window is the time between evaluations, the miss rates are measured over it.
+	window time.Duration
+
+	// minSize and maxSize bound the L1 cache capacity.
+	minSize int
+	maxSize int
+
+	// growMissRate is the miss rate above which the L1 cache grows.
+	growMissRate float64
+
+	// shrinkMissRate is the miss rate below which the L1 cache shrinks.
+	shrinkMissRate float64
+}
+
+func (a *adaptiveFastPathParameters) IsEnabled() bool {
+	return a.enabled
+}
+
+func (a *adaptiveFastPathParameters) GetWindow() time.Duration {
+	return a.window
+}
+
+func (a *adaptiveFastPathParameters) GetMinSize() int {
+	return a.minSize
+}
+
+func (a *adaptiveFastPathParameters) GetMaxSize() int {
+	return a.maxSize
+}
+
+func (a *adaptiveFastPathParameters) GetGrowMissRate() float64 {
+	return a.growMissRate
+}
+
+func (a *adaptiveFastPathParameters) GetShrinkMissRate() float64 {
+	return a.shrinkMissRate
+}
+
+// objectTrackingParameters controls the optional metadata the pool keeps for each object:
+// when it was created, how many times it was handed out, how long it was held and by whom.
+type objectTrackingParameters struct {
+	// enabled makes the pool track every object it creates.
+	enabled bool
+
+	// maxReuses is the number of times an object is handed out before Put retires it
+	// instead of storing it, zero means no limit.
+	maxReuses int
+
+	// captureCaller records the file and line of the caller of Get, at the cost of a
+	// stack lookup on every checkout.
+	captureCaller bool
+}
+
+func (o *objectTrackingParameters) IsEnabled() bool {
+	return o.enabled
+}
+
+func (o *objectTrackingParameters) GetMaxReuses() int {
+	return o.maxReuses
+}
+
+func (o *objectTrackingParameters) IsCaptureCaller() bool {
+	return o.captureCaller
+}
+
+// holdTimeoutParameters controls the optional limit on how long a single checkout may last.
+// A background goroutine looks for objects held past maxHoldTime every checkInterval.
+type holdTimeoutParameters struct {
+	// enabled starts the hold time checks, it requires object tracking.
+	enabled bool
+
+	// maxHoldTime is how long a caller may hold an object before it's reported.
+	maxHoldTime time.Duration
+
+	// checkInterval is the time between checks.
+	checkInterval time.Duration
+
+	// reclaim stops counting timed out objects as in use and allocates replacements,
+	// so a caller that never returns its object doesn't starve the pool.
+	reclaim bool
+}
+
+func (h *holdTimeoutParameters) IsEnabled() bool {
+	return h.enabled
+}
+
+func (h *holdTimeoutParameters) GetMaxHoldTime() time.Duration {
+	return h.maxHoldTime
+}
+
+func (h *holdTimeoutParameters) GetCheckInterval() time.Duration {
+	return h.checkInterval
+}
+
+func (h *holdTimeoutParameters) IsReclaim() bool {
+	return h.reclaim
+}
+
 // shrinkDefaults provides default values for shrink parameters.
 // These defaults are used when specific parameters are not configured.
 type shrinkDefaults struct {
@@ -340,7 +610,25 @@ type shrinkDefaults struct {
 	maxShrinks int
 }
 
+// AllocationMode selects how many objects the pool allocates ahead of demand.
+type AllocationMode int
+
+const (
+	// AllocationPercent preallocates AllocPercent of the capacity at initialization and when
+	// growing, and creates AllocAmount objects per request when L1 is empty.
+	AllocationPercent AllocationMode = iota
+
+	// AllocationLazy allocates nothing ahead of demand, objects are created one per request.
+	AllocationLazy
+
+	// AllocationEager fills the whole capacity at initialization and when growing.
+	AllocationEager
+)
+
 type AllocationStrategy struct {
+	// Mode selects how many objects are allocated ahead of demand
+	Mode AllocationMode
+
 	// The percentage of objects to preallocate at initialization
 	// The percentage of objects to fill the pool up to when growing
 	AllocPercent int
@@ -348,4 +636,47 @@ type AllocationStrategy struct {
 	// The amount of objects to create per request
 	// If it exceeds the ring buffer capacity it will be adjusted to the ring buffer capacity.
 	AllocAmount int
+
+	// The number of consecutive allocator failures after which allocation is paused
+	// Zero disables the failure backoff.
+	FailureThreshold int
+
+	// How long allocation stays paused once FailureThreshold is reached
+	FailureBackoff time.Duration
+}
+
+// RetryMode selects how the slow paths of Get and Put wait between retries.
+type RetryMode int
+
+const (
+	// RetryNone fails on the first error.
+	RetryNone RetryMode = iota
+
+	// RetryFixed waits InitialDelay between retries.
+	RetryFixed
+
+	// RetryExponential doubles the wait after each retry, starting at InitialDelay and capped at MaxDelay.
+	RetryExponential
+)
+
+// RetryPolicy configures the retries of the ring buffer operations in the slow paths of Get and Put.
+type RetryPolicy struct {
+	// Mode selects the wait between retries.
+	Mode RetryMode
+
+	// The maximum number of retries after the first attempt
+	MaxRetries int
+
+	// The wait before the first retry
+	InitialDelay time.Duration
+
+	// The upper bound of the wait between retries, zero means no bound
+	MaxDelay time.Duration
+
+	// The fraction of the wait randomly added or removed from each wait, between 0 and 1
+	Jitter float64
+
+	// The maximum time spent retrying, a retry that would exceed it isn't attempted.
+	// Zero means no limit.
+	MaxElapsed time.Duration
 }
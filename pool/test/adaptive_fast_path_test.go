@@ -0,0 +1,124 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAdaptiveFastPath drives the adaptive sizing goroutine with a fake clock. The effects of a
+// window are asserted after the following tick is delivered, which guarantees they are complete.
+func TestAdaptiveFastPath(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(32).
+		SetHardLimit(32).
+		SetMinShrinkCapacity(32).
+		SetFastPathInitialSize(2).
+		SetFastPathGrowthConfigs(8, 1.0, 0).
+		SetFastPathShrinkConfigs(50, 0).
+		SetAdaptiveFastPathConfigs(2, 12, time.Second, 0.2, 0.05).
+		SetClock(clock).
+		Build()
+	require.NoError(t, err)
+
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	// The shrink goroutine and the adaptive sizing goroutine.
+	require.True(t, clock.WaitForTickers(2, time.Second))
+
+	window := func() {
+		clock.Advance(time.Second)
+		clock.Advance(time.Second)
+	}
+
+	burst := func() {
+		objects := make([]*TestObject, 0, 16)
+		for range 16 {
+			obj, err := p.Get()
+			require.NoError(t, err)
+			objects = append(objects, obj)
+		}
+		for _, obj := range objects {
+			require.NoError(t, p.Put(obj))
+		}
+	}
+
+	steady := func() {
+		for range 50 {
+			obj, err := p.Get()
+			require.NoError(t, err)
+			require.NoError(t, p.Put(obj))
+		}
+	}
+
+	// Bursts spill most returns to the ring buffer, so L1 doubles each window up to maxSize.
+	for _, want := range []int{4, 8, 12, 12} {
+		burst()
+		window()
+		stats := p.GetPoolStatsSnapshot()
+		assert.Equal(t, want, stats.CurrentL1Capacity)
+		require.NoError(t, pooltest.CheckInvariants(p))
+	}
+	assert.Positive(t, p.GetPoolStatsSnapshot().L1GetMisses)
+
+	// A steady load is served by L1 alone, so it shrinks by half each window down to minSize.
+	for _, want := range []int{6, 3, 2, 2} {
+		steady()
+		window()
+		stats := p.GetPoolStatsSnapshot()
+		assert.Equal(t, want, stats.CurrentL1Capacity)
+		assert.LessOrEqual(t, stats.L1Length, want)
+		require.NoError(t, pooltest.CheckInvariants(p))
+	}
+
+	// Windows without traffic leave L1 as it is.
+	window()
+	assert.Equal(t, 2, p.GetPoolStatsSnapshot().CurrentL1Capacity)
+
+	stats := p.GetPoolStatsSnapshot()
+	assert.Zero(t, stats.TotalGrowthEvents)
+	assert.Equal(t, stats.ObjectsCreated-stats.ObjectsDestroyed, stats.L1Length+stats.RingBufferLength)
+}
+
+func TestAdaptiveFastPathConfigValidation(t *testing.T) {
+	testValidConfig(t, "defaults within bounds", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetAdaptiveFastPathConfigs(0, 0, 0, 0, 0).
+			Build()
+	})
+
+	testInvalidConfig(t, "initial size below min size", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetFastPathInitialSize(4).
+			SetAdaptiveFastPathConfigs(8, 64, time.Second, 0, 0).
+			Build()
+	})
+
+	testInvalidConfig(t, "max size below min size", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetAdaptiveFastPathConfigs(128, 64, time.Second, 0, 0).
+			Build()
+	})
+
+	testInvalidConfig(t, "shrink miss rate above grow miss rate", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetAdaptiveFastPathConfigs(8, 128, time.Second, 0.1, 0.2).
+			Build()
+	})
+
+	testInvalidConfig(t, "grow miss rate above one", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetAdaptiveFastPathConfigs(8, 128, time.Second, 1.5, 0).
+			Build()
+	})
+}
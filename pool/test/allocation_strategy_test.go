@@ -1,10 +1,13 @@
 package test
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/AlexsanderHamir/PoolX/v2/pool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -134,16 +137,19 @@ func TestAllocationStrategyBehavior(t *testing.T) {
 }
 
 func TestAllocationStrategyWithShrink(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
 	config, err := pool.NewPoolConfigBuilder[*TestObject]().
 		SetInitialCapacity(50).
 		SetHardLimit(100).
 		SetAllocationStrategy(50, 5). // 50% allocation percent, 5 objects per allocation
 		SetMinShrinkCapacity(10).
-		SetShrinkCheckInterval(10 * time.Millisecond).
-		SetShrinkCooldown(10 * time.Millisecond).
-		SetMinUtilizationBeforeShrink(90).  // Shrink if utilization below 10%
+		SetShrinkCheckInterval(time.Second).
+		SetShrinkCooldown(time.Second).
+		SetMinUtilizationBeforeShrink(90).  // Shrink if utilization below 90%
 		SetStableUnderutilizationRounds(1). // Only need 1 round of underutilization
 		SetShrinkPercent(50).               // Shrink by 50%
+		SetClock(clock).
 		Build()
 	require.NoError(t, err)
 
@@ -166,14 +172,204 @@ func TestAllocationStrategyWithShrink(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	// Wait for shrink to occur
-	time.Sleep(100 * time.Millisecond)
+	require.True(t, clock.WaitForTickers(1, time.Second))
+
+	// Ticks 1 and 2 shrink 50 -> 25 -> 12, reaching the default maxConsecutiveShrinks,
+	// so tick 3 is blocked until the pool grows.
+	for range 3 {
+		clock.Advance(time.Second)
+	}
 
 	// Verify shrink and allocation behavior
 	stats = p.GetPoolStatsSnapshot()
-	assert.True(t, p.IsShrunk())                                          // Pool should have shrunk
+	assert.True(t, p.IsShrunk())
+	assert.Equal(t, 12, stats.CurrentCapacity)
+	assert.Equal(t, 2, stats.TotalShrinkEvents)
+	assert.Equal(t, 2, stats.ConsecutiveShrinks)
 	assert.Equal(t, uint64(0), stats.ObjectsInUse)                        // All objects should be returned
 	assert.Equal(t, uint64(10), stats.TotalGets)                          // Should have 10 total gets
 	assert.Equal(t, uint64(10), stats.FastReturnHit+stats.FastReturnMiss) // All objects should be returned
 	assert.Equal(t, 25, stats.ObjectsCreated)                             // No new objects should be created
 }
+
+func TestAllocationModes(t *testing.T) {
+	tests := []struct {
+		name            string
+		mode            pool.AllocationMode
+		expectedInitial int
+		expectedAfter   int
+	}{
+		{name: "percent", mode: pool.AllocationPercent, expectedInitial: 5, expectedAfter: 5},
+		{name: "lazy", mode: pool.AllocationLazy, expectedInitial: 0, expectedAfter: 1},
+		{name: "eager", mode: pool.AllocationEager, expectedInitial: 10, expectedAfter: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := buildTestConfig(t, newTestConfigBuilder().SetInitialCapacity(10).SetAllocationStrategy(50, 5).SetAllocationMode(tt.mode))
+			p := createTestPool(t, config)
+			defer func() {
+				require.NoError(t, p.Close())
+			}()
+
+			assert.Equal(t, tt.expectedInitial, p.GetPoolStatsSnapshot().ObjectsCreated)
+
+			obj, err := p.Get()
+			require.NoError(t, err)
+			require.NotNil(t, obj)
+			assert.Equal(t, tt.expectedAfter, p.GetPoolStatsSnapshot().ObjectsCreated)
+			require.NoError(t, p.Put(obj))
+		})
+	}
+}
+
+func TestAllocationLazyValidatesFirstAllocation(t *testing.T) {
+	var calls atomic.Int64
+	allocator := func() *TestObject {
+		if calls.Add(1) == 1 {
+			return nil
+		}
+		return &TestObject{Value: 42}
+	}
+	cleaner := func(obj *TestObject) {
+		obj.Value = 0
+	}
+
+	config := buildTestConfig(t, newTestConfigBuilder().SetInitialCapacity(10).SetAllocationStrategy(50, 5).SetAllocationMode(pool.AllocationLazy))
+	p, err := pool.NewPool(config, allocator, cleaner, nil)
+	require.NoError(t, err, "lazy pools don't allocate at construction")
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+	assert.Zero(t, calls.Load())
+
+	_, err = p.Get()
+	require.ErrorIs(t, err, pool.ErrAllocationFailed)
+
+	obj, err := p.Get()
+	require.NoError(t, err)
+	assert.Equal(t, 42, obj.Value)
+	require.NoError(t, p.Put(obj))
+}
+
+func TestAllocationLazyValidatesTypeAtConstruction(t *testing.T) {
+	allocator := func() *TestObject {
+		return &TestObject{}
+	}
+
+	config := buildTestConfig(t, newTestConfigBuilder().SetInitialCapacity(10).SetAllocationStrategy(50, 5).SetAllocationMode(pool.AllocationLazy))
+	_, err := pool.NewPool(config, allocator, nil, nil)
+	assert.Error(t, err, "nil cleaner")
+
+	fallibleAllocator := func(context.Context) (*TestObject, error) {
+		return &TestObject{}, nil
+	}
+	_, err = pool.NewPoolWithFallibleAllocator(config, fallibleAllocator, nil, nil)
+	assert.Error(t, err, "nil cleaner")
+
+	valueConfig, err := pool.NewPoolConfigBuilder[TestObject]().
+		SetAllocationMode(pool.AllocationLazy).
+		Build()
+	require.NoError(t, err)
+
+	_, err = pool.NewPool(valueConfig, func() TestObject { return TestObject{} }, func(TestObject) {}, nil)
+	assert.Error(t, err, "non-pointer type")
+
+	_, err = pool.NewValuePool(valueConfig, func() TestObject { return TestObject{} }, nil)
+	assert.Error(t, err, "nil cleaner")
+}
+
+func TestAllocationEagerFillsAfterGrowth(t *testing.T) {
+	config := buildTestConfig(t, newTestConfigBuilder().SetInitialCapacity(10).SetAllocationStrategy(50, 5).SetAllocationMode(pool.AllocationEager))
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	objects := make([]*TestObject, 0, 11)
+	for range 11 {
+		obj, err := p.Get()
+		require.NoError(t, err)
+		objects = append(objects, obj)
+	}
+
+	stats := p.GetPoolStatsSnapshot()
+	require.Positive(t, stats.TotalGrowthEvents)
+	assert.Equal(t, stats.CurrentCapacity, stats.ObjectsCreated, "growth fills the new capacity")
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestAllocBatch(t *testing.T) {
+	var batches []int
+	allocBatch := func(n int) []*TestObject {
+		batches = append(batches, n)
+
+		slab := make([]TestObject, n)
+		objs := make([]*TestObject, n)
+		for i := range slab {
+			objs[i] = &slab[i]
+		}
+		return objs
+	}
+
+	config := buildTestConfig(t, newTestConfigBuilder().SetInitialCapacity(10).SetAllocationStrategy(50, 5).SetAllocationMode(pool.AllocationPercent).SetAllocBatch(allocBatch))
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	// The first object comes from the allocator, which it validates.
+	require.Equal(t, []int{4}, batches, "the initial objects come from a single batch")
+	assert.Equal(t, 5, p.GetPoolStatsSnapshot().ObjectsCreated)
+
+	objects := make([]*TestObject, 0, 8)
+	for range 8 {
+		obj, err := p.Get()
+		require.NoError(t, err)
+		objects = append(objects, obj)
+	}
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+
+	assert.Len(t, batches, 2, "on demand objects come from a single batch as well")
+	assert.Equal(t, 10, p.GetPoolStatsSnapshot().ObjectsCreated)
+
+	require.NoError(t, p.Prewarm(3))
+	assert.Equal(t, 3, batches[len(batches)-1])
+}
+
+func TestAllocBatchShortAndEmpty(t *testing.T) {
+	empty := false
+	allocBatch := func(n int) []*TestObject {
+		if empty {
+			return nil
+		}
+		return []*TestObject{{}, nil}
+	}
+
+	config := buildTestConfig(t, newTestConfigBuilder().SetInitialCapacity(10).SetAllocationStrategy(50, 5).SetAllocationMode(pool.AllocationPercent).SetAllocBatch(allocBatch))
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	// Nil objects are skipped and short batches accepted, next to the first object.
+	assert.Equal(t, 2, p.GetPoolStatsSnapshot().ObjectsCreated)
+
+	empty = true
+	err := p.Prewarm(2)
+	assert.ErrorIs(t, err, pool.ErrAllocationFailed)
+	assert.Equal(t, 2, p.GetPoolStatsSnapshot().ObjectsCreated)
+}
+
+func TestAllocationModeConfig(t *testing.T) {
+	testInvalidConfig(t, "unknown mode", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetAllocationMode(pool.AllocationMode(42)).
+			Build()
+	})
+}
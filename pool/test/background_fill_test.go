@@ -0,0 +1,143 @@
+package test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackgroundFill(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(32).
+		SetHardLimit(64).
+		SetMinShrinkCapacity(32).
+		SetFastPathInitialSize(8).
+		SetAllocationStrategy(10, 1).
+		SetBackgroundFillConfigs(24, 10*time.Millisecond).
+		Build()
+	require.NoError(t, err)
+
+	allocator := func() *TestObject {
+		time.Sleep(time.Millisecond)
+		return &TestObject{Value: 42}
+	}
+
+	cleaner := func(obj *TestObject) {
+		obj.Value = 0
+	}
+
+	p, err := pool.NewPool(config, allocator, cleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	poolObj := p.(*pool.Pool[*TestObject])
+
+	assert.Eventually(t, func() bool {
+		stats := poolObj.GetPoolStatsSnapshot()
+		return stats.L1Length+stats.RingBufferLength >= 24
+	}, 2*time.Second, 10*time.Millisecond)
+
+	objects := make([]*TestObject, 20)
+	for i := range objects {
+		objects[i], err = p.Get()
+		require.NoError(t, err)
+		require.NotNil(t, objects[i])
+	}
+
+	assert.Eventually(t, func() bool {
+		stats := poolObj.GetPoolStatsSnapshot()
+		return stats.L1Length+stats.RingBufferLength >= 12
+	}, 2*time.Second, 10*time.Millisecond)
+
+	stats := poolObj.GetPoolStatsSnapshot()
+	assert.LessOrEqual(t, stats.ObjectsCreated, stats.CurrentCapacity)
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestBackgroundFillDropsObjectsWithoutRoom(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(32).
+		SetHardLimit(64).
+		SetMinShrinkCapacity(4).
+		SetFastPathInitialSize(4).
+		SetAllocationStrategy(10, 1).
+		SetBackgroundFillConfigs(24, 10*time.Millisecond).
+		SetObjectTrackingConfigs(0, false).
+		Build()
+	require.NoError(t, err)
+
+	// The first 3 objects are the initial ones, the filler's allocations block until unblocked.
+	var calls atomic.Int64
+	allocating := make(chan struct{}, 1)
+	unblock := make(chan struct{})
+	allocator := func() *TestObject {
+		if calls.Add(1) > 3 {
+			select {
+			case allocating <- struct{}{}:
+			default:
+			}
+			<-unblock
+		}
+		return &TestObject{Value: 42}
+	}
+
+	var cleaned atomic.Int64
+	cleaner := func(obj *TestObject) {
+		cleaned.Add(1)
+	}
+
+	p, err := pool.NewPool(config, allocator, cleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+	poolObj := p.(*pool.Pool[*TestObject])
+
+	// The filler is allocating, the room it reserved is taken away before it hands the objects in.
+	<-allocating
+	require.NoError(t, poolObj.ResizeTo(4))
+	require.NoError(t, poolObj.ResizeL1To(1))
+	close(unblock)
+
+	assert.Eventually(t, func() bool {
+		return cleaned.Load() > 0
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		stats := poolObj.GetPoolStatsSnapshot()
+		usage, ok := poolObj.ObjectUsage()
+		live := stats.ObjectsCreated - stats.ObjectsDestroyed
+		return ok && live == stats.L1Length+stats.RingBufferLength && usage.TrackedObjects == live
+	}, 2*time.Second, 10*time.Millisecond, "dropped objects are destroyed and no longer tracked")
+}
+
+func TestBackgroundFillConfigurations(t *testing.T) {
+	testInvalidConfig(t, "zero low watermark", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetBackgroundFillConfigs(0, time.Second).
+			Build()
+	})
+
+	testInvalidConfig(t, "low watermark above hard limit", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetHardLimit(100).
+			SetBackgroundFillConfigs(101, time.Second).
+			Build()
+	})
+
+	testValidConfig(t, "default interval", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetBackgroundFillConfigs(10, 0).
+			Build()
+	})
+}
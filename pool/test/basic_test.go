@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/AlexsanderHamir/PoolX/v2/pool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -57,18 +58,23 @@ func TestPoolGrowth(t *testing.T) {
 	}
 }
 
+// TestPoolShrink drives the shrink goroutine with a fake clock. The effects of a tick are
+// asserted after the following tick is delivered, which guarantees they are complete.
 func TestPoolShrink(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
 	config, err := pool.NewPoolConfigBuilder[*TestObject]().
 		SetInitialCapacity(32).
 		EnforceCustomConfig().
-		SetShrinkCheckInterval(10*time.Millisecond). // Very frequent checks
-		SetShrinkCooldown(10*time.Millisecond).      // Short cooldown
-		SetMinUtilizationBeforeShrink(90).           // Shrink if utilization below 10%
-		SetStableUnderutilizationRounds(1).          // Only need 1 round of underutilization
-		SetShrinkPercent(50).                        // Shrink by 50%
-		SetMinShrinkCapacity(1).                     // Can shrink down to 1
-		SetMaxConsecutiveShrinks(5).                 // Allow multiple consecutive shrinks
+		SetShrinkCheckInterval(time.Second).
+		SetShrinkCooldown(2*time.Second).
+		SetMinUtilizationBeforeShrink(90).  // Shrink if utilization below 90%
+		SetStableUnderutilizationRounds(1). // Only need 1 round of underutilization
+		SetShrinkPercent(50).               // Shrink by 50%
+		SetMinShrinkCapacity(1).            // Can shrink down to 1
+		SetMaxConsecutiveShrinks(2).
 		SetFastPathBasicConfigs(32, 1, 1, 100, 20).
+		SetClock(clock).
 		Build()
 	require.NoError(t, err)
 
@@ -90,6 +96,7 @@ func TestPoolShrink(t *testing.T) {
 	defer func() {
 		require.NoError(t, p.Close())
 	}()
+	poolObj := p.(*pool.Pool[*TestObject])
 
 	objects := make([]*TestObject, 10)
 	for i := range 10 {
@@ -98,13 +105,35 @@ func TestPoolShrink(t *testing.T) {
 		assert.NotNil(t, objects[i])
 	}
 
-	time.Sleep(300 * time.Millisecond)
-	poolObj := p.(*pool.Pool[*TestObject])
+	require.True(t, clock.WaitForTickers(1, time.Second))
+	start := clock.Now()
 
+	tick := func() {
+		clock.Advance(time.Second)
+	}
+
+	// Tick 1 shrinks 32 -> 16, tick 2 falls within the cooldown.
+	tick()
+	tick()
+	stats := poolObj.GetPoolStatsSnapshot()
+	assert.Equal(t, 16, stats.CurrentCapacity)
+	assert.Equal(t, 1, stats.TotalShrinkEvents)
+	assert.Equal(t, 1, stats.ConsecutiveShrinks)
+	assert.Equal(t, start.Add(time.Second), stats.LastShrinkTime)
+
+	// Tick 3 shrinks 16 -> 10, keeping room for the objects in use, and reaches
+	// maxConsecutiveShrinks, so tick 4 is blocked until the pool grows.
+	tick()
+	tick()
+	stats = poolObj.GetPoolStatsSnapshot()
+	assert.Equal(t, 10, stats.CurrentCapacity)
+	assert.Equal(t, 2, stats.TotalShrinkEvents)
+	assert.Equal(t, 2, stats.ConsecutiveShrinks)
+	assert.Equal(t, start.Add(3*time.Second), stats.LastShrinkTime)
 	assert.True(t, poolObj.IsShrunk())
 
 	for _, obj := range objects {
-		p.Put(obj)
+		require.NoError(t, p.Put(obj))
 	}
 }
 
@@ -137,6 +166,47 @@ func TestHardLimit(t *testing.T) {
 	})
 }
 
+// A single object pool must still fill L1, whatever the fill aggressiveness.
+func TestSingleObjectPool(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(1).
+		SetHardLimit(1).
+		SetMinShrinkCapacity(1).
+		SetFastPathInitialSize(1).
+		Build()
+	require.NoError(t, err)
+
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	obj, err := p.Get()
+	require.NoError(t, err)
+	require.NotNil(t, obj)
+	require.NoError(t, p.Put(obj))
+
+	again, err := p.Get()
+	require.NoError(t, err)
+	assert.Same(t, obj, again)
+	require.NoError(t, p.Put(again))
+}
+
+func TestInitialL1Fill(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder().
+		SetFastPathFillAggressiveness(50).
+		SetAllocationStrategy(100, 4)))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	// Half of the 8 L1 slots are filled, the first object counting like the others.
+	stats := p.GetPoolStatsSnapshot()
+	assert.Equal(t, 16, stats.ObjectsCreated)
+	assert.Equal(t, 4, stats.L1Length)
+	assert.Equal(t, 12, stats.RingBufferLength)
+}
+
 func TestConfigValues(t *testing.T) {
 	defaultConfig, err := pool.NewPoolConfigBuilder[*TestObject]().Build()
 	require.NoError(t, err)
@@ -0,0 +1,182 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// objectSize estimates every test object at 10 bytes.
+func objectSize(*TestObject) int {
+	return 10
+}
+
+func TestBudgetCharge(t *testing.T) {
+	budget, err := pool.NewBudget(400)
+	require.NoError(t, err)
+
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetShrinkPercent(50).
+		SetShrinkCheckInterval(time.Hour))
+	a := createTestPool(t, config)
+	b := createTestPool(t, config)
+
+	require.NoError(t, a.JoinBudget(budget, objectSize))
+	require.NoError(t, b.JoinBudget(budget, objectSize))
+	assert.Equal(t, int64(320), budget.Used())
+
+	assert.Error(t, a.JoinBudget(budget, objectSize), "joining twice should fail")
+
+	require.NoError(t, a.ResizeTo(24))
+	assert.Equal(t, int64(400), budget.Used())
+	assert.Equal(t, int64(0), budget.Available())
+
+	require.NoError(t, a.ResizeTo(8))
+	assert.Equal(t, int64(240), budget.Used())
+
+	require.NoError(t, a.Close())
+	assert.Equal(t, int64(160), budget.Used())
+
+	require.NoError(t, b.Close())
+	assert.Equal(t, int64(0), budget.Used())
+}
+
+func TestBudgetReclaimsFromOtherPools(t *testing.T) {
+	budget, err := pool.NewBudget(400)
+	require.NoError(t, err)
+
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetShrinkPercent(50).
+		SetShrinkCheckInterval(time.Hour))
+	a := createTestPool(t, config)
+	b := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, a.Close())
+		require.NoError(t, b.Close())
+	}()
+
+	require.NoError(t, a.JoinBudget(budget, objectSize))
+	require.NoError(t, b.JoinBudget(budget, objectSize))
+
+	require.NoError(t, a.ResizeTo(32), "growth should shrink the idle pool to make room")
+	assert.Less(t, b.GetPoolStatsSnapshot().CurrentCapacity, 16)
+	assert.LessOrEqual(t, budget.Used(), budget.Limit())
+
+	err = a.ResizeTo(40)
+	assert.ErrorIs(t, err, pool.ErrBudgetExhausted)
+	assert.Equal(t, 32, a.GetPoolStatsSnapshot().CurrentCapacity)
+	assert.LessOrEqual(t, budget.Used(), budget.Limit())
+}
+
+func TestBudgetRefusesGrowth(t *testing.T) {
+	budget, err := pool.NewBudget(200)
+	require.NoError(t, err)
+
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetShrinkPercent(50).
+		SetShrinkCheckInterval(time.Hour))
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	require.NoError(t, p.JoinBudget(budget, objectSize))
+
+	assert.ErrorIs(t, p.Prewarm(30), pool.ErrBudgetExhausted)
+	assert.Equal(t, 16, p.GetPoolStatsSnapshot().CurrentCapacity)
+	assert.Equal(t, int64(160), budget.Used())
+}
+
+func TestBudgetInvalidArguments(t *testing.T) {
+	_, err := pool.NewBudget(0)
+	assert.ErrorIs(t, err, pool.ErrInvalidCapacity)
+
+	budget, err := pool.NewBudget(100)
+	require.NoError(t, err)
+
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetShrinkPercent(50).
+		SetShrinkCheckInterval(time.Hour))
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	assert.Error(t, p.JoinBudget(budget, nil))
+	assert.ErrorIs(t, p.JoinBudget(budget, objectSize), pool.ErrBudgetExhausted)
+	assert.Error(t, p.JoinBudget(nil, objectSize))
+}
+
+func TestBudgetVariableObjectSizes(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[*TestBuffer]().
+		SetInitialCapacity(16).
+		SetHardLimit(64).
+		SetMinShrinkCapacity(4).
+		SetShrinkCheckInterval(time.Hour).
+		SetFastPathInitialSize(8).
+		SetAllocationStrategy(50, 4).
+		Build()
+	require.NoError(t, err)
+
+	var allocated int
+	allocator := func() *TestBuffer {
+		allocated++
+		if allocated%2 == 0 {
+			return &TestBuffer{Data: make([]byte, 30)}
+		}
+		return &TestBuffer{Data: make([]byte, 10)}
+	}
+	cleaner := func(buf *TestBuffer) {
+		buf.Data = buf.Data[:0]
+	}
+
+	poolObj, err := pool.NewPool(config, allocator, cleaner, nil)
+	require.NoError(t, err)
+	p := poolObj.(*pool.Pool[*TestBuffer])
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	budget, err := pool.NewBudget(1000)
+	require.NoError(t, err)
+
+	sizeOf := func(buf *TestBuffer) int {
+		return cap(buf.Data)
+	}
+
+	require.NoError(t, p.JoinBudget(budget, sizeOf))
+	assert.Equal(t, int64(16*20), budget.Used(), "every slot is charged the mean size of the stored objects")
+
+	require.NoError(t, p.ResizeTo(32))
+	assert.Equal(t, int64(32*20), budget.Used())
+}
+
+func TestBudgetReclaimRespectsShrinkCooldown(t *testing.T) {
+	budget, err := pool.NewBudget(400)
+	require.NoError(t, err)
+
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetShrinkPercent(50).
+		SetShrinkCheckInterval(time.Hour))
+	a := createTestPool(t, config)
+	b := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, a.Close())
+		require.NoError(t, b.Close())
+	}()
+
+	require.NoError(t, a.JoinBudget(budget, objectSize))
+	require.NoError(t, b.JoinBudget(budget, objectSize))
+
+	require.NoError(t, a.ResizeTo(32))
+	require.Equal(t, 8, b.GetPoolStatsSnapshot().CurrentCapacity)
+
+	// Shrinking b to its minimum capacity of 4 would make room, but it's still in its cooldown.
+	err = a.ResizeTo(36)
+	assert.ErrorIs(t, err, pool.ErrBudgetExhausted)
+	assert.Equal(t, 8, b.GetPoolStatsSnapshot().CurrentCapacity)
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestShrinkWithFakeClock drives the shrink goroutine tick by tick. The effects of a tick are
+// asserted after the following tick is delivered, which guarantees they are complete.
+func TestShrinkWithFakeClock(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(32).
+		SetHardLimit(64).
+		SetRingBufferShrinkConfigs(time.Second, 5*time.Second, 2, 4, 2, 10, 50).
+		SetClock(clock).
+		Build()
+	require.NoError(t, err)
+
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	require.True(t, clock.WaitForTickers(1, time.Second))
+
+	tick := func() {
+		clock.Advance(time.Second)
+	}
+
+	// Ticks 1 and 2 reach the stable underutilization rounds and shrink 32 -> 16.
+	tick()
+	tick()
+	tick()
+	stats := p.GetPoolStatsSnapshot()
+	assert.Equal(t, 16, stats.CurrentCapacity)
+	assert.Equal(t, 1, stats.TotalShrinkEvents)
+
+	// Ticks 3 to 6 fall within the cooldown, tick 7 counts one underutilized round.
+	for range 4 {
+		tick()
+	}
+	stats = p.GetPoolStatsSnapshot()
+	assert.Equal(t, 16, stats.CurrentCapacity)
+	assert.Equal(t, 1, stats.TotalShrinkEvents)
+
+	// Tick 8 shrinks 16 -> 8, reaching maxConsecutiveShrinks, tick 9 is blocked until growth.
+	tick()
+	tick()
+	stats = p.GetPoolStatsSnapshot()
+	assert.Equal(t, 8, stats.CurrentCapacity)
+	assert.Equal(t, 2, stats.TotalShrinkEvents)
+	assert.Equal(t, 2, stats.ConsecutiveShrinks)
+	assert.Equal(t, clock.Now().Add(-time.Second), stats.LastShrinkTime)
+
+	// The shrinker is parked until growth with its ticker stopped, so further ticks don't block Advance.
+	advanced := make(chan struct{})
+	go func() {
+		defer close(advanced)
+		for range 5 {
+			tick()
+		}
+	}()
+
+	select {
+	case <-advanced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Advance blocked on the parked shrinker")
+	}
+
+	stats = p.GetPoolStatsSnapshot()
+	assert.Equal(t, 8, stats.CurrentCapacity)
+	assert.Equal(t, 2, stats.TotalShrinkEvents)
+}
+
+func TestAllocationBackoffWithFakeClock(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(10).
+		SetHardLimit(20).
+		SetMinShrinkCapacity(10).
+		SetAllocationStrategy(50, 1).
+		SetAllocationFailureBackoff(2, time.Minute).
+		SetRetryPolicy(pool.RetryPolicy{Mode: pool.RetryNone}).
+		SetClock(clock).
+		Build()
+	require.NoError(t, err)
+
+	var calls atomic.Int64
+	allocator := failingAllocator(5, &calls)
+	cleaner := func(obj *TestObject) {
+		obj.Value = 0
+	}
+
+	p, err := pool.NewPoolWithFallibleAllocator(config, allocator, cleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	objects := make([]*TestObject, 5)
+	for i := range objects {
+		objects[i], err = p.Get()
+		require.NoError(t, err)
+	}
+
+	_, err = p.Get()
+	require.ErrorIs(t, err, pool.ErrAllocationFailed)
+
+	callsDuringBackoff := calls.Load()
+	clock.Advance(59 * time.Second)
+	_, err = p.Get()
+	require.ErrorIs(t, err, pool.ErrAllocationFailed)
+	assert.Equal(t, callsDuringBackoff, calls.Load())
+
+	clock.Advance(time.Second)
+	_, err = p.Get()
+	require.ErrorIs(t, err, pool.ErrAllocationFailed)
+	assert.Greater(t, calls.Load(), callsDuringBackoff)
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
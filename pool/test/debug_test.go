@@ -0,0 +1,172 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentCapacityEvents(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	assert.Empty(t, p.RecentCapacityEvents())
+
+	require.NoError(t, p.ResizeTo(32))
+	require.NoError(t, p.ResizeTo(8))
+
+	events := p.RecentCapacityEvents()
+	require.Len(t, events, 2)
+	assert.Equal(t, pool.CapacityEvent{Time: events[0].Time, Kind: pool.CapacityResize, From: 16, To: 32}, events[0])
+	assert.Equal(t, pool.CapacityEvent{Time: events[1].Time, Kind: pool.CapacityResize, From: 32, To: 8}, events[1])
+
+	for i := range 40 {
+		require.NoError(t, p.ResizeTo(8+i%2))
+	}
+
+	events = p.RecentCapacityEvents()
+	assert.Len(t, events, 32, "only the most recent events are kept")
+	assert.Equal(t, 8, events[len(events)-1].From)
+	assert.Equal(t, 9, events[len(events)-1].To)
+}
+
+func TestRegistry(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	registry := pool.NewRegistry()
+	require.NoError(t, registry.Register("b", p))
+	require.NoError(t, registry.Register("a", p))
+
+	assert.Error(t, registry.Register("a", p))
+	assert.Error(t, registry.Register("", p))
+	assert.Error(t, registry.Register("c", nil))
+
+	infos := registry.Inspect()
+	require.Len(t, infos, 2)
+	assert.Equal(t, "a", infos[0].Name)
+	assert.Equal(t, "b", infos[1].Name)
+
+	registry.Unregister("a")
+	infos = registry.Inspect()
+	require.Len(t, infos, 1)
+	assert.Equal(t, "b", infos[0].Name)
+}
+
+func TestRegistryKeyedPool(t *testing.T) {
+	kp, err := pool.NewKeyedPool[string](buildTestConfig(t, newTestConfigBuilder().
+		SetInitialCapacity(4).
+		SetHardLimit(8).
+		SetFastPathInitialSize(2)), 20, 0, testAllocator, testCleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, kp.Close())
+	}()
+
+	registry := pool.NewRegistry()
+	require.NoError(t, registry.Register("tenants", kp))
+
+	a, err := kp.Get("tenant-a")
+	require.NoError(t, err)
+	b, err := kp.Get("tenant-b")
+	require.NoError(t, err)
+
+	infos := registry.Inspect()
+	require.Len(t, infos, 1)
+
+	info := infos[0]
+	assert.Equal(t, "tenants", info.Name)
+	assert.Equal(t, uint64(2), info.OutstandingObjects)
+	assert.Equal(t, 8, info.Stats.CurrentCapacity, "the capacities of both keys")
+	assert.Contains(t, info.Config, pool.ConfigSetting{Name: "hardLimit", Value: "8"})
+	assert.Contains(t, info.Config, pool.ConfigSetting{Name: "keyed.globalHardLimit", Value: "20"})
+	assert.Contains(t, info.Config, pool.ConfigSetting{Name: "keyed.keys", Value: "2"})
+
+	require.NoError(t, kp.Put("tenant-a", a))
+	require.NoError(t, kp.Put("tenant-b", b))
+}
+
+func TestDebugHandler(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	registry := pool.NewRegistry()
+	require.NoError(t, registry.Register("objects", p))
+
+	obj, err := p.Get()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Put(obj))
+	}()
+	require.NoError(t, p.ResizeTo(32))
+
+	handler := pool.DebugHandler(registry)
+
+	serve := func(target string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("json", func(t *testing.T) {
+		for _, rec := range []*httptest.ResponseRecorder{
+			serve("/debug/poolx?format=json", nil),
+			serve("/debug/poolx", http.Header{"Accept": {"application/json"}}),
+		} {
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+
+			var infos []pool.PoolDebugInfo
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &infos))
+			require.Len(t, infos, 1)
+
+			info := infos[0]
+			assert.Equal(t, "objects", info.Name)
+			assert.Equal(t, uint64(1), info.OutstandingObjects)
+			assert.Equal(t, 32, info.Stats.CurrentCapacity)
+			assert.Equal(t, 0, info.BlockedReaders)
+			require.NotEmpty(t, info.RecentEvents)
+			assert.Equal(t, pool.CapacityResize, info.RecentEvents[len(info.RecentEvents)-1].Kind)
+			assert.Contains(t, info.Config, pool.ConfigSetting{Name: "hardLimit", Value: "64"})
+			assert.Contains(t, info.Config, pool.ConfigSetting{Name: "fastPath.initialSize", Value: "8"})
+		}
+	})
+
+	t.Run("html", func(t *testing.T) {
+		rec := serve("/debug/poolx", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+
+		body := rec.Body.String()
+		assert.True(t, strings.HasPrefix(body, "<!DOCTYPE html>"))
+		assert.Contains(t, body, "<h2 id=\"objects\">objects</h2>")
+		assert.Contains(t, body, "<th>hardLimit</th><td>64</td>")
+		assert.Contains(t, body, "<td>resize</td><td>16</td><td>32</td>")
+		assert.Contains(t, body, "<th>Utilization</th><td>3.12%</td>", "one of 32 objects in use")
+	})
+
+	t.Run("name", func(t *testing.T) {
+		rec := serve("/debug/poolx?format=json&name=objects", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		rec = serve("/debug/poolx?name=missing", nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
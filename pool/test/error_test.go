@@ -318,7 +318,7 @@ func TestResourceCleanup(t *testing.T) {
 	err = p.Close()
 	require.NoError(t, err)
 
-	validation := 3
+	validation := 2
 	movedToL1 := 64
 	assert.Equal(t, int64(objNum+validation), created.Load())
 	assert.Equal(t, int64(objNum+movedToL1), cleaned.Load())
@@ -0,0 +1,195 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDialFailed = errors.New("dial failed")
+
+// failingAllocator returns an allocator that succeeds for the first successes calls and fails afterwards.
+func failingAllocator(successes int64, calls *atomic.Int64) func(context.Context) (*TestObject, error) {
+	return func(ctx context.Context) (*TestObject, error) {
+		if calls.Add(1) > successes {
+			return nil, errDialFailed
+		}
+		return &TestObject{Value: 42}, nil
+	}
+}
+
+func TestFallibleAllocatorPropagatesErrors(t *testing.T) {
+	var calls atomic.Int64
+	cleaner := func(obj *TestObject) {
+		obj.Value = 0
+	}
+
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetInitialCapacity(10).
+		SetAllocationStrategy(50, 1).
+		SetAllocationFailureBackoff(2, 200*time.Millisecond))
+	p, err := pool.NewPoolWithFallibleAllocator(config, failingAllocator(7, &calls), cleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	var objects []*TestObject
+	for {
+		obj, err := p.Get()
+		if err != nil {
+			assert.ErrorIs(t, err, pool.ErrAllocationFailed)
+			assert.ErrorIs(t, err, errDialFailed)
+			break
+		}
+		require.NotNil(t, obj)
+		objects = append(objects, obj)
+		require.Less(t, len(objects), 20)
+	}
+
+	assert.Len(t, objects, 7)
+
+	stats := p.(*pool.Pool[*TestObject]).GetPoolStatsSnapshot()
+	assert.Equal(t, 7, stats.ObjectsCreated)
+
+	require.NoError(t, p.Put(objects[0]))
+	obj, err := p.Get()
+	require.NoError(t, err)
+	objects[0] = obj
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestFallibleAllocatorBackoff(t *testing.T) {
+	var calls atomic.Int64
+	cleaner := func(obj *TestObject) {
+		obj.Value = 0
+	}
+
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetInitialCapacity(10).
+		SetAllocationStrategy(50, 1).
+		SetAllocationFailureBackoff(2, 200*time.Millisecond))
+	p, err := pool.NewPoolWithFallibleAllocator(config, failingAllocator(5, &calls), cleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	objects := make([]*TestObject, 5)
+	for i := range objects {
+		objects[i], err = p.Get()
+		require.NoError(t, err)
+	}
+
+	_, err = p.Get()
+	require.ErrorIs(t, err, pool.ErrAllocationFailed)
+
+	callsAfterBackoff := calls.Load()
+	_, err = p.Get()
+	require.ErrorIs(t, err, pool.ErrAllocationFailed)
+	assert.Equal(t, callsAfterBackoff, calls.Load(), "allocator should not be called while backing off")
+
+	time.Sleep(250 * time.Millisecond)
+
+	_, err = p.Get()
+	require.ErrorIs(t, err, pool.ErrAllocationFailed)
+	assert.Greater(t, calls.Load(), callsAfterBackoff)
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestFallibleAllocatorFirstObjectFailure(t *testing.T) {
+	var calls atomic.Int64
+	cleaner := func(obj *TestObject) {
+		obj.Value = 0
+	}
+
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetInitialCapacity(10).
+		SetAllocationStrategy(50, 1).
+		SetAllocationFailureBackoff(2, 200*time.Millisecond))
+	p, err := pool.NewPoolWithFallibleAllocator(config, failingAllocator(0, &calls), cleaner, nil)
+	assert.ErrorIs(t, err, pool.ErrAllocationFailed)
+	assert.Nil(t, p)
+}
+
+func TestFallibleAllocatorInitializationFailure(t *testing.T) {
+	var calls, cleaned atomic.Int64
+	cleaner := func(obj *TestObject) {
+		cleaned.Add(1)
+	}
+
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetInitialCapacity(10).
+		SetAllocationStrategy(50, 1).
+		SetAllocationFailureBackoff(2, 200*time.Millisecond))
+	p, err := pool.NewPoolWithFallibleAllocator(config, failingAllocator(3, &calls), cleaner, nil)
+	assert.ErrorIs(t, err, errDialFailed)
+	assert.Nil(t, p)
+	assert.Equal(t, int64(3), cleaned.Load(), "the objects created before the failure are cleaned")
+}
+
+func TestFallibleAllocatorGrowthStatsOnFillFailure(t *testing.T) {
+	var calls atomic.Int64
+	cleaner := func(obj *TestObject) {
+		obj.Value = 0
+	}
+
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetInitialCapacity(10).
+		SetAllocationStrategy(50, 1).
+		SetAllocationFailureBackoff(2, 200*time.Millisecond))
+	p, err := pool.NewPoolWithFallibleAllocator(config, failingAllocator(10, &calls), cleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	var objects []*TestObject
+	for range 15 {
+		obj, err := p.Get()
+		if err != nil {
+			require.ErrorIs(t, err, pool.ErrAllocationFailed)
+			continue
+		}
+		objects = append(objects, obj)
+	}
+	require.Len(t, objects, 10)
+
+	stats := p.(*pool.Pool[*TestObject]).GetPoolStatsSnapshot()
+	require.Greater(t, stats.CurrentCapacity, stats.InitialCapacity, "failed Gets still grow the pool")
+	assert.Positive(t, stats.TotalGrowthEvents, "growths whose fill failed are recorded")
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestNonPointerTypeIsRejectedBeforeAllocating(t *testing.T) {
+	var allocated, cleaned atomic.Int64
+	allocator := func() TestObject {
+		allocated.Add(1)
+		return TestObject{Value: 42}
+	}
+	cleaner := func(obj TestObject) {
+		cleaned.Add(1)
+	}
+
+	p, err := pool.NewPool[TestObject](nil, allocator, cleaner, nil)
+	require.Error(t, err)
+	assert.Nil(t, p)
+	assert.Zero(t, allocated.Load(), "the type is checked before the first object is allocated")
+	assert.Zero(t, cleaned.Load())
+}
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleRelease(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	h, err := p.GetHandle()
+	require.NoError(t, err)
+
+	obj := h.Value()
+	require.NotNil(t, obj)
+	assert.Same(t, obj, h.Value())
+	assert.False(t, h.Released())
+	assert.Equal(t, uint64(1), p.GetPoolStatsSnapshot().ObjectsInUse)
+
+	require.NoError(t, h.Release())
+	assert.True(t, h.Released())
+	assert.Equal(t, uint64(0), p.GetPoolStatsSnapshot().ObjectsInUse)
+
+	assert.PanicsWithValue(t, pool.ErrHandleReleased, func() {
+		h.Value()
+	})
+
+	// Only the first release returns the object.
+	require.NoError(t, h.Release())
+	stats := p.GetPoolStatsSnapshot()
+	assert.Equal(t, uint64(0), stats.ObjectsInUse)
+	assert.Equal(t, stats.TotalGets, stats.FastReturnHit+stats.FastReturnMiss)
+	require.NoError(t, pooltest.CheckInvariants(p))
+}
+
+func TestHandleConcurrentRelease(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	h, err := p.GetHandle()
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	for range 8 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			assert.NoError(t, h.Release())
+		}()
+	}
+	for range 8 {
+		<-done
+	}
+
+	stats := p.GetPoolStatsSnapshot()
+	assert.Equal(t, uint64(0), stats.ObjectsInUse)
+	assert.Equal(t, uint64(1), stats.FastReturnHit+stats.FastReturnMiss)
+}
+
+func TestHandleDebugPoison(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder().
+		SetHandleDebugConfigs(func(obj *TestObject) {
+			obj.Value = -1
+		})))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	h, err := p.GetHandle()
+	require.NoError(t, err)
+
+	leaked := h.Value()
+	leaked.Value = 42
+	require.NoError(t, h.Release())
+
+	assert.Equal(t, -1, leaked.Value, "released objects are poisoned")
+
+	stats := p.GetPoolStatsSnapshot()
+	assert.Equal(t, uint64(0), stats.ObjectsInUse)
+	assert.Equal(t, 1, stats.ObjectsDestroyed)
+	require.NoError(t, pooltest.CheckSnapshotInvariants(stats))
+
+	for range 32 {
+		next, err := p.GetHandle()
+		require.NoError(t, err)
+		assert.NotSame(t, leaked, next.Value(), "poisoned objects are never handed out again")
+		require.NoError(t, next.Release())
+	}
+
+	assert.Equal(t, -1, leaked.Value)
+	require.NoError(t, pooltest.CheckInvariants(p))
+}
@@ -190,26 +190,54 @@ func createHardLimitTestConfig(t *testing.T, blocking bool) *pool.PoolConfig[*Te
 	return config
 }
 
-// createTestPool creates a pool with the given configuration
-func createTestPool(t *testing.T, config *pool.PoolConfig[*TestObject]) *pool.Pool[*TestObject] {
-	allocator := func() *TestObject {
-		return &TestObject{Value: 42}
-	}
+// newTestConfigBuilder returns a builder for a small pool that can grow, shrink and use its
+// L1 cache, tests add the settings they exercise on top of it.
+func newTestConfigBuilder() pool.PoolConfigBuilder[*TestObject] {
+	return pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(16).
+		SetHardLimit(64).
+		SetMinShrinkCapacity(4).
+		SetFastPathInitialSize(8)
+}
 
-	cleaner := func(obj *TestObject) {
-		obj.Value = 0
-	}
+// buildTestConfig builds the configuration of builder, failing the test if it's invalid
+func buildTestConfig(t *testing.T, builder pool.PoolConfigBuilder[*TestObject]) *pool.PoolConfig[*TestObject] {
+	config, err := builder.Build()
+	require.NoError(t, err)
+	return config
+}
+
+func testAllocator() *TestObject {
+	return &TestObject{Value: 42}
+}
 
+func testCleaner(obj *TestObject) {
+	obj.Value = 0
+}
+
+// createTestPool creates a pool with the given configuration
+func createTestPool(t *testing.T, config *pool.PoolConfig[*TestObject]) *pool.Pool[*TestObject] {
 	cloneTemplate := func(obj *TestObject) *TestObject {
 		dst := *obj
 		return &dst
 	}
 
-	p, err := pool.NewPool(config, allocator, cleaner, cloneTemplate)
+	p, err := pool.NewPool(config, testAllocator, testCleaner, cloneTemplate)
 	require.NoError(t, err)
 	return p.(*pool.Pool[*TestObject])
 }
 
+// getObjects takes n objects from p, failing the test if any Get fails
+func getObjects(t *testing.T, p *pool.Pool[*TestObject], n int) []*TestObject {
+	objects := make([]*TestObject, n)
+	for i := range objects {
+		obj, err := p.Get()
+		require.NoError(t, err)
+		objects[i] = obj
+	}
+	return objects
+}
+
 func runNilReturnTest(t *testing.T, p *pool.Pool[*TestObject]) {
 	objects := make([]*TestObject, 100)
 
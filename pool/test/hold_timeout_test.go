@@ -0,0 +1,200 @@
+package test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type holdTimeout struct {
+	obj    *TestObject
+	holder string
+}
+
+func createHoldTimeoutPool(t *testing.T, clock *pooltest.FakeClock, reclaim bool) (*pool.Pool[*TestObject], chan holdTimeout) {
+	timeouts := make(chan holdTimeout, 16)
+
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder().
+		SetObjectTrackingConfigs(0, true).
+		SetHoldTimeoutConfigs(100*time.Millisecond, 50*time.Millisecond, reclaim, func(obj *TestObject, holder string) {
+			timeouts <- holdTimeout{obj: obj, holder: holder}
+		}).
+		SetClock(clock)))
+
+	// The shrink and hold timeout goroutines.
+	require.True(t, clock.WaitForTickers(2, time.Second))
+	return p, timeouts
+}
+
+func TestHoldTimeoutCallback(t *testing.T) {
+	clock := newTestClock()
+	p, timeouts := createHoldTimeoutPool(t, clock, false)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	obj, err := p.Get()
+	require.NoError(t, err)
+
+	// Held for 100ms at the second check, which isn't over the limit yet.
+	clock.Advance(50 * time.Millisecond)
+	clock.Advance(50 * time.Millisecond)
+	assert.Empty(t, timeouts)
+
+	clock.Advance(50 * time.Millisecond)
+
+	select {
+	case timeout := <-timeouts:
+		assert.Same(t, obj, timeout.obj)
+		assert.Contains(t, timeout.holder, "hold_timeout_test.go")
+	case <-time.After(time.Second):
+		t.Fatal("hold timeout wasn't reported")
+	}
+
+	// Each checkout is reported once.
+	clock.Advance(50 * time.Millisecond)
+	clock.Advance(50 * time.Millisecond)
+	assert.Empty(t, timeouts)
+
+	usage, ok := p.ObjectUsage()
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), usage.HoldTimeouts)
+	assert.Zero(t, usage.ReclaimedObjects)
+	assert.Equal(t, uint64(1), p.GetPoolStatsSnapshot().ObjectsInUse)
+
+	require.NoError(t, p.Put(obj))
+	assert.Equal(t, uint64(0), p.GetPoolStatsSnapshot().ObjectsInUse)
+
+	meta, ok := p.ObjectMetadata(obj)
+	require.True(t, ok)
+	assert.Equal(t, 250*time.Millisecond, meta.TotalHoldTime)
+}
+
+func TestHoldTimeoutReclaim(t *testing.T) {
+	clock := newTestClock()
+	p, timeouts := createHoldTimeoutPool(t, clock, true)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	obj, err := p.Get()
+	require.NoError(t, err)
+	before := p.GetPoolStatsSnapshot()
+
+	for range 3 {
+		clock.Advance(50 * time.Millisecond)
+	}
+
+	select {
+	case timeout := <-timeouts:
+		assert.Same(t, obj, timeout.obj)
+	case <-time.After(time.Second):
+		t.Fatal("hold timeout wasn't reported")
+	}
+
+	after := p.GetPoolStatsSnapshot()
+	assert.Equal(t, uint64(0), after.ObjectsInUse, "the reclaimed object no longer counts as in use")
+	assert.Equal(t, before.ObjectsDestroyed+1, after.ObjectsDestroyed)
+	assert.Equal(t, before.ObjectsCreated+1, after.ObjectsCreated, "a replacement is allocated")
+	require.NoError(t, pooltest.CheckSnapshotInvariants(after))
+
+	usage, ok := p.ObjectUsage()
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), usage.HoldTimeouts)
+	assert.Equal(t, uint64(1), usage.ReclaimedObjects)
+
+	// The holder returns the object late, it's dropped instead of being stored.
+	require.NoError(t, p.Put(obj))
+
+	final := p.GetPoolStatsSnapshot()
+	assert.Equal(t, uint64(0), final.ObjectsInUse)
+	assert.Equal(t, after.ObjectsDestroyed, final.ObjectsDestroyed)
+	require.NoError(t, pooltest.CheckInvariants(p))
+
+	_, ok = p.ObjectMetadata(obj)
+	assert.False(t, ok)
+
+	for range 32 {
+		next, err := p.Get()
+		require.NoError(t, err)
+		assert.NotSame(t, obj, next, "reclaimed objects are never handed out again")
+		require.NoError(t, p.Put(next))
+	}
+}
+
+func TestHoldTimeoutLateReturnIsCleaned(t *testing.T) {
+	clock := newTestClock()
+
+	var cleaned atomic.Int64
+	cleaner := func(obj *TestObject) {
+		cleaned.Add(1)
+		testCleaner(obj)
+	}
+
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetObjectTrackingConfigs(0, true).
+		SetHoldTimeoutConfigs(100*time.Millisecond, 50*time.Millisecond, true, nil).
+		SetHandleDebugConfigs(func(obj *TestObject) {
+			obj.Value = -1
+		}).
+		SetClock(clock))
+	poolObj, err := pool.NewPool(config, testAllocator, cleaner, nil)
+	require.NoError(t, err)
+	p := poolObj.(*pool.Pool[*TestObject])
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+	require.True(t, clock.WaitForTickers(2, time.Second))
+
+	obj, err := p.Get()
+	require.NoError(t, err)
+	h, err := p.GetHandle()
+	require.NoError(t, err)
+
+	// Reclaimed at the third check, complete once the fourth tick is received.
+	for range 4 {
+		clock.Advance(50 * time.Millisecond)
+	}
+
+	usage, ok := p.ObjectUsage()
+	require.True(t, ok)
+	require.Equal(t, uint64(2), usage.ReclaimedObjects)
+
+	// Both holders return their objects late, they're dropped but still cleaned.
+	obj.Value = 7
+	require.NoError(t, p.Put(obj))
+	assert.Equal(t, int64(1), cleaned.Load())
+	assert.Zero(t, obj.Value)
+
+	leaked := h.Value()
+	require.NoError(t, h.Release())
+	assert.Equal(t, int64(2), cleaned.Load())
+	assert.Equal(t, -1, leaked.Value, "the object is cleaned before it's poisoned")
+
+	require.NoError(t, pooltest.CheckInvariants(p))
+}
+
+func TestHoldTimeoutConfig(t *testing.T) {
+	testInvalidConfig(t, "zero max hold time", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetHoldTimeoutConfigs(0, time.Second, false, nil).
+			Build()
+	})
+
+	t.Run("enables object tracking", func(t *testing.T) {
+		config, err := pool.NewPoolConfigBuilder[*TestObject]().
+			SetHoldTimeoutConfigs(time.Minute, 0, true, nil).
+			Build()
+		require.NoError(t, err)
+
+		assert.True(t, config.GetObjectTracking().IsEnabled())
+		assert.Equal(t, time.Second, config.GetHoldTimeout().GetCheckInterval())
+		assert.True(t, config.GetHoldTimeout().IsReclaim())
+	})
+}
@@ -0,0 +1,261 @@
+package test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyedPoolLazyCreation(t *testing.T) {
+	kp, err := pool.NewKeyedPool[string](buildTestConfig(t, newTestConfigBuilder()), 0, 0, testAllocator, testCleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, kp.Close())
+	}()
+
+	assert.Equal(t, 0, kp.Len())
+
+	a, err := kp.Get("tenant-a")
+	require.NoError(t, err)
+	b, err := kp.Get("tenant-b")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, kp.Len())
+
+	_, ok := kp.Pool("tenant-a")
+	assert.True(t, ok)
+	_, ok = kp.Pool("tenant-c")
+	assert.False(t, ok)
+
+	require.NoError(t, kp.Put("tenant-a", a))
+	require.NoError(t, kp.Put("tenant-b", b))
+
+	assert.Error(t, kp.Put("tenant-c", &TestObject{}))
+}
+
+func TestKeyedPoolGlobalHardLimit(t *testing.T) {
+	// Every key preallocates up to its capacity of 4, a third key would exceed the limit.
+	kp, err := pool.NewKeyedPool[string](buildTestConfig(t, newTestConfigBuilder().
+		SetInitialCapacity(4).
+		SetHardLimit(8).
+		SetFastPathInitialSize(2)), 10, 0, testAllocator, testCleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, kp.Close())
+	}()
+
+	a, err := kp.Get("a")
+	require.NoError(t, err)
+	b, err := kp.Get("b")
+	require.NoError(t, err)
+
+	_, err = kp.Get("c")
+	assert.ErrorIs(t, err, pool.ErrHardLimitExceeded)
+	assert.Equal(t, 2, kp.Len())
+
+	// Growing "a" to its own hard limit of 8 would exceed the global one as well.
+	objects := []*TestObject{a}
+	for range 7 {
+		obj, err := kp.Get("a")
+		if err != nil {
+			break
+		}
+		objects = append(objects, obj)
+	}
+	assert.Less(t, len(objects), 8)
+
+	stats := kp.GetPoolStatsSnapshot()
+	assert.LessOrEqual(t, stats.CurrentCapacity, 10)
+	assert.LessOrEqual(t, stats.ObjectsCreated-stats.ObjectsDestroyed, 10)
+
+	for _, obj := range objects {
+		require.NoError(t, kp.Put("a", obj))
+	}
+	require.NoError(t, kp.Put("b", b))
+}
+
+func TestKeyedPoolIdleEviction(t *testing.T) {
+	kp, err := pool.NewKeyedPool[string](buildTestConfig(t, newTestConfigBuilder()), 0, 50*time.Millisecond, testAllocator, testCleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, kp.Close())
+	}()
+
+	idle, err := kp.Get("idle")
+	require.NoError(t, err)
+	require.NoError(t, kp.Put("idle", idle))
+
+	held, err := kp.Get("held")
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		_, ok := kp.Pool("idle")
+		return !ok
+	}, 2*time.Second, 10*time.Millisecond)
+
+	_, ok := kp.Pool("held")
+	assert.True(t, ok, "keys with objects in use must not be evicted")
+
+	require.NoError(t, kp.Put("held", held))
+}
+
+func TestKeyedPoolTinyIdleTimeout(t *testing.T) {
+	kp, err := pool.NewKeyedPool[string](buildTestConfig(t, newTestConfigBuilder()), 0, time.Nanosecond, testAllocator, testCleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, kp.Close())
+	}()
+
+	obj, err := kp.Get("a")
+	require.NoError(t, err)
+	require.NoError(t, kp.Put("a", obj))
+
+	assert.Eventually(t, func() bool {
+		return kp.Len() == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestKeyedPoolPutWithoutGet(t *testing.T) {
+	kp, err := pool.NewKeyedPool[string](buildTestConfig(t, newTestConfigBuilder()), 0, 0, testAllocator, testCleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, kp.Close())
+	}()
+
+	obj, err := kp.Get("a")
+	require.NoError(t, err)
+	require.NoError(t, kp.Put("a", obj))
+
+	assert.Error(t, kp.Put("a", &TestObject{}), "no object of the key is checked out")
+
+	obj, err = kp.Get("a")
+	require.NoError(t, err)
+	require.NoError(t, kp.Put("a", obj))
+}
+
+func TestKeyedPoolCloseWithObjectsInUse(t *testing.T) {
+	kp, err := pool.NewKeyedPool[string](buildTestConfig(t, newTestConfigBuilder()), 0, 0, testAllocator, testCleaner, nil)
+	require.NoError(t, err)
+
+	a, err := kp.Get("a")
+	require.NoError(t, err)
+	b, err := kp.Get("b")
+	require.NoError(t, err)
+
+	closed := make(chan error)
+	start := time.Now()
+	go func() {
+		closed <- kp.Close()
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, kp.Put("a", a), "keys stay reachable until their pools are closed")
+	assert.NoError(t, kp.Put("b", b))
+
+	require.NoError(t, <-closed)
+	assert.Less(t, time.Since(start), 5*time.Second, "pools are closed concurrently once their objects are back")
+	assert.Equal(t, 0, kp.Len())
+
+	_, err = kp.Get("a")
+	assert.Error(t, err)
+}
+
+func TestKeyedPoolCreationDoesNotBlockOtherKeys(t *testing.T) {
+	var blocked atomic.Bool
+	unblock := make(chan struct{})
+	allocator := func() *TestObject {
+		if blocked.Load() {
+			<-unblock
+		}
+		return &TestObject{Value: 42}
+	}
+	cleaner := func(obj *TestObject) {}
+
+	kp, err := pool.NewKeyedPool[string](nil, 0, 0, allocator, cleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, kp.Close())
+	}()
+
+	obj, err := kp.Get("ready")
+	require.NoError(t, err)
+	require.NoError(t, kp.Put("ready", obj))
+
+	blocked.Store(true)
+	created := make(chan error)
+	go func() {
+		obj, err := kp.Get("slow")
+		if err == nil {
+			err = kp.Put("slow", obj)
+		}
+		created <- err
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		obj, err := kp.Get("ready")
+		assert.NoError(t, err)
+		assert.NoError(t, kp.Put("ready", obj))
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Get of an existing key waited for the creation of another key's pool")
+	}
+
+	blocked.Store(false)
+	close(unblock)
+	require.NoError(t, <-created)
+	<-done
+}
+
+func TestKeyedPoolAggregatedStats(t *testing.T) {
+	kp, err := pool.NewKeyedPool[string](buildTestConfig(t, newTestConfigBuilder()), 0, 0, testAllocator, testCleaner, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, kp.Close())
+	}()
+
+	keys := []string{"a", "b", "c"}
+	objects := make(map[string]*TestObject)
+	for _, key := range keys {
+		obj, err := kp.Get(key)
+		require.NoError(t, err)
+		objects[key] = obj
+	}
+
+	stats := kp.GetPoolStatsSnapshot()
+	assert.Equal(t, uint64(3), stats.ObjectsInUse)
+	assert.Equal(t, uint64(3), stats.TotalGets)
+	assert.Equal(t, 3*16, stats.InitialCapacity)
+
+	for key, obj := range objects {
+		require.NoError(t, kp.Put(key, obj))
+	}
+
+	stats = kp.GetPoolStatsSnapshot()
+	assert.Equal(t, uint64(0), stats.ObjectsInUse)
+}
+
+func TestKeyedPoolInvalidArguments(t *testing.T) {
+	allocator := func() *TestObject {
+		return &TestObject{}
+	}
+	cleaner := func(obj *TestObject) {}
+
+	_, err := pool.NewKeyedPool[string](nil, -1, 0, allocator, cleaner, nil)
+	assert.Error(t, err)
+
+	_, err = pool.NewKeyedPool[string](nil, 0, -time.Second, allocator, cleaner, nil)
+	assert.Error(t, err)
+
+	_, err = pool.NewKeyedPool[string, *TestObject](nil, 0, 0, nil, cleaner, nil)
+	assert.Error(t, err)
+}
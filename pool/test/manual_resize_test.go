@@ -0,0 +1,112 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrewarm(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	before := p.GetPoolStatsSnapshot()
+
+	require.NoError(t, p.Prewarm(20))
+
+	after := p.GetPoolStatsSnapshot()
+	assert.Equal(t, before.ObjectsCreated+20, after.ObjectsCreated)
+	assert.GreaterOrEqual(t, after.CurrentCapacity, after.ObjectsCreated)
+	assert.Equal(t, after.CurrentCapacity, p.RingBufferCapacity())
+
+	err := p.Prewarm(64)
+	assert.ErrorIs(t, err, pool.ErrHardLimitExceeded)
+
+	err = p.Prewarm(0)
+	assert.ErrorIs(t, err, pool.ErrInvalidCapacity)
+}
+
+func TestResizeTo(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	require.NoError(t, p.ResizeTo(48))
+	assert.Equal(t, 48, p.RingBufferCapacity())
+	assert.True(t, p.IsRingBufferGrowth())
+
+	require.NoError(t, p.ResizeTo(8))
+	assert.Equal(t, 8, p.RingBufferCapacity())
+	assert.True(t, p.IsRingBufferShrunk())
+
+	assert.ErrorIs(t, p.ResizeTo(65), pool.ErrHardLimitExceeded)
+	assert.ErrorIs(t, p.ResizeTo(0), pool.ErrInvalidCapacity)
+
+	objects := make([]*TestObject, 4)
+	for i := range objects {
+		obj, err := p.Get()
+		require.NoError(t, err)
+		require.NotNil(t, obj)
+		objects[i] = obj
+	}
+
+	assert.ErrorIs(t, p.ResizeTo(2), pool.ErrInvalidCapacity)
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestResizeL1To(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	require.NoError(t, p.ResizeL1To(32))
+	assert.True(t, p.IsFastPathGrowth())
+	assert.Equal(t, 32, p.GetPoolStatsSnapshot().CurrentL1Capacity)
+
+	require.NoError(t, p.ResizeL1To(2))
+	stats := p.GetPoolStatsSnapshot()
+	assert.Equal(t, 2, stats.CurrentL1Capacity)
+	assert.LessOrEqual(t, stats.L1Length, 2)
+
+	assert.ErrorIs(t, p.ResizeL1To(-1), pool.ErrInvalidCapacity)
+	assert.ErrorIs(t, p.ResizeL1To(100), pool.ErrHardLimitExceeded)
+
+	obj, err := p.Get()
+	require.NoError(t, err)
+	require.NotNil(t, obj)
+	require.NoError(t, p.Put(obj))
+}
+
+func TestShrinkNow(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	require.NoError(t, p.ShrinkNow())
+	assert.True(t, p.IsRingBufferShrunk())
+
+	require.NoError(t, p.ResizeTo(4))
+	assert.ErrorIs(t, p.ShrinkNow(), pool.ErrShrinkRejected)
+}
+
+func TestManualResizeAfterClose(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+	require.NoError(t, p.Close())
+
+	assert.ErrorContains(t, p.Prewarm(1), "pool is closed")
+	assert.ErrorContains(t, p.ResizeTo(3), "pool is closed")
+	assert.ErrorContains(t, p.ResizeL1To(3), "pool is closed")
+	assert.ErrorContains(t, p.ShrinkNow(), "pool is closed")
+	assert.NotEqual(t, 3, p.RingBufferCapacity(), "a closed pool keeps its ring buffer")
+}
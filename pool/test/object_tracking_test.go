@@ -0,0 +1,167 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectMetadata(t *testing.T) {
+	clock := newTestClock()
+	config := buildTestConfig(t, newTestConfigBuilder().SetObjectTrackingConfigs(0, true).SetClock(clock))
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	obj, err := p.Get()
+	require.NoError(t, err)
+
+	meta, ok := p.ObjectMetadata(obj)
+	require.True(t, ok)
+	assert.Equal(t, clock.Now(), meta.CreatedAt)
+	assert.Equal(t, 1, meta.ReuseCount)
+	assert.Equal(t, clock.Now(), meta.LastCheckout)
+	assert.True(t, strings.Contains(meta.LastCaller, "object_tracking_test.go"), "caller was %q", meta.LastCaller)
+
+	clock.Advance(50 * time.Millisecond)
+	require.NoError(t, p.Put(obj))
+
+	meta, ok = p.ObjectMetadata(obj)
+	require.True(t, ok)
+	assert.Equal(t, 50*time.Millisecond, meta.TotalHoldTime)
+
+	usage, ok := p.ObjectUsage()
+	require.True(t, ok)
+	assert.Equal(t, p.GetPoolStatsSnapshot().ObjectsCreated, usage.TrackedObjects)
+	assert.Zero(t, usage.RetiredObjects)
+
+	var checkouts uint64
+	for _, bucket := range usage.HoldTimes {
+		checkouts += bucket.Count
+		if bucket.UpTo == 100*time.Millisecond {
+			assert.Equal(t, uint64(1), bucket.Count)
+		}
+	}
+	assert.Equal(t, uint64(1), checkouts)
+
+	reused := 0
+	for _, bucket := range usage.Reuses {
+		if bucket.UpTo == 1 {
+			reused = bucket.Count
+		}
+	}
+	assert.Equal(t, 1, reused)
+}
+
+func TestObjectMetadataTryGetAndPriority(t *testing.T) {
+	clock := newTestClock()
+	config := buildTestConfig(t, newTestConfigBuilder().SetObjectTrackingConfigs(0, true).SetClock(clock))
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	obj, ok := p.TryGet()
+	require.True(t, ok)
+
+	meta, ok := p.ObjectMetadata(obj)
+	require.True(t, ok)
+	assert.Equal(t, 1, meta.ReuseCount)
+	assert.Contains(t, meta.LastCaller, "object_tracking_test.go")
+	require.NoError(t, p.Put(obj))
+
+	obj, err := p.GetWithPriority(context.Background(), 0)
+	require.NoError(t, err)
+
+	meta, ok = p.ObjectMetadata(obj)
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, meta.ReuseCount, 1)
+	assert.Contains(t, meta.LastCaller, "object_tracking_test.go")
+	require.NoError(t, p.Put(obj))
+}
+
+func TestMaxReuses(t *testing.T) {
+	clock := newTestClock()
+	config := buildTestConfig(t, newTestConfigBuilder().SetObjectTrackingConfigs(1, true).SetClock(clock))
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	obj, err := p.Get()
+	require.NoError(t, err)
+	require.NoError(t, p.Put(obj))
+
+	_, ok := p.ObjectMetadata(obj)
+	assert.False(t, ok, "the object is retired after its last use")
+
+	usage, ok := p.ObjectUsage()
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), usage.RetiredObjects)
+
+	stats := p.GetPoolStatsSnapshot()
+	assert.Equal(t, 1, stats.ObjectsDestroyed)
+	assert.Equal(t, uint64(0), stats.ObjectsInUse)
+	require.NoError(t, pooltest.CheckSnapshotInvariants(stats))
+
+	for range 32 {
+		next, err := p.Get()
+		require.NoError(t, err)
+		assert.NotSame(t, obj, next, "retired objects are never handed out again")
+		require.NoError(t, p.Put(next))
+	}
+
+	usage, _ = p.ObjectUsage()
+	assert.Equal(t, uint64(33), usage.RetiredObjects)
+	require.NoError(t, pooltest.CheckInvariants(p))
+}
+
+func TestObjectTrackingDisabled(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	obj, err := p.Get()
+	require.NoError(t, err)
+
+	_, ok := p.ObjectMetadata(obj)
+	assert.False(t, ok)
+
+	_, ok = p.ObjectUsage()
+	assert.False(t, ok)
+
+	require.NoError(t, p.Put(obj))
+}
+
+func TestObjectTrackingConfig(t *testing.T) {
+	testInvalidConfig(t, "negative max reuses", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetObjectTrackingConfigs(-1, false).
+			Build()
+	})
+
+	testValidConfig(t, "unlimited reuses", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetObjectTrackingConfigs(0, false).
+			Build()
+	})
+
+	t.Run("value pool", func(t *testing.T) {
+		config, err := pool.NewPoolConfigBuilder[[]byte]().
+			SetObjectTrackingConfigs(0, false).
+			Build()
+		require.NoError(t, err)
+
+		_, err = pool.NewValuePool(config, func() []byte { return make([]byte, 0, 8) }, func(b []byte) []byte { return b[:0] })
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,156 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exhaustRetryTestPool creates a non-blocking pool with the given retry policy and takes all its objects.
+// A nil clock uses the system clock.
+func exhaustRetryTestPool(t *testing.T, policy pool.RetryPolicy, clock pool.Clock) (*pool.Pool[*TestObject], []*TestObject) {
+	config := buildTestConfig(t, newTestConfigBuilder().
+		SetInitialCapacity(4).
+		SetHardLimit(4).
+		SetFastPathInitialSize(2).
+		SetRingBufferBlocking(false).
+		SetRetryPolicy(policy).
+		SetClock(clock))
+
+	p := createTestPool(t, config)
+	return p, getObjects(t, p, 4)
+}
+
+func TestRetryPolicyNone(t *testing.T) {
+	p, objects := exhaustRetryTestPool(t, pool.RetryPolicy{Mode: pool.RetryNone}, nil)
+	defer func() {
+		for _, obj := range objects {
+			require.NoError(t, p.Put(obj))
+		}
+		require.NoError(t, p.Close())
+	}()
+
+	start := time.Now()
+	_, err := p.Get()
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 10*time.Millisecond)
+	assert.Equal(t, uint64(0), p.GetPoolStatsSnapshot().TotalRetries)
+}
+
+func TestRetryPolicyFixed(t *testing.T) {
+	p, objects := exhaustRetryTestPool(t, pool.RetryPolicy{
+		Mode:         pool.RetryFixed,
+		MaxRetries:   3,
+		InitialDelay: 5 * time.Millisecond,
+	}, nil)
+	defer func() {
+		for _, obj := range objects {
+			require.NoError(t, p.Put(obj))
+		}
+		require.NoError(t, p.Close())
+	}()
+
+	start := time.Now()
+	_, err := p.Get()
+	assert.Error(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+	assert.Equal(t, uint64(3), p.GetPoolStatsSnapshot().TotalRetries)
+}
+
+func TestRetryPolicyExponentialMaxElapsed(t *testing.T) {
+	p, objects := exhaustRetryTestPool(t, pool.RetryPolicy{
+		Mode:         pool.RetryExponential,
+		MaxRetries:   10,
+		InitialDelay: 2 * time.Millisecond,
+		Jitter:       0.2,
+		MaxElapsed:   20 * time.Millisecond,
+	}, nil)
+	defer func() {
+		for _, obj := range objects {
+			require.NoError(t, p.Put(obj))
+		}
+		require.NoError(t, p.Close())
+	}()
+
+	start := time.Now()
+	_, err := p.Get()
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 40*time.Millisecond)
+
+	retries := p.GetPoolStatsSnapshot().TotalRetries
+	assert.Greater(t, retries, uint64(0))
+	assert.Less(t, retries, uint64(10))
+}
+
+func TestRetryPolicyWithFakeClock(t *testing.T) {
+	clock := newTestClock()
+	p, objects := exhaustRetryTestPool(t, pool.RetryPolicy{
+		Mode:         pool.RetryFixed,
+		MaxRetries:   5,
+		InitialDelay: time.Hour,
+		MaxElapsed:   150 * time.Minute,
+	}, clock)
+	defer func() {
+		for _, obj := range objects {
+			require.NoError(t, p.Put(obj))
+		}
+		require.NoError(t, p.Close())
+	}()
+
+	done := make(chan error)
+	go func() {
+		_, err := p.Get()
+		done <- err
+	}()
+
+	// The third retry would end past MaxElapsed, so Get gives up after two hours.
+	for range 2 {
+		// The shrink ticker and the timer of the retry.
+		require.True(t, clock.WaitForTickers(2, time.Second))
+		clock.Advance(time.Hour)
+	}
+
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Get didn't give up once MaxElapsed was reached on the pool clock")
+	}
+	assert.Equal(t, uint64(2), p.GetPoolStatsSnapshot().TotalRetries)
+}
+
+func TestRetryPolicyConfigurations(t *testing.T) {
+	testInvalidConfig(t, "negative max retries", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetRetryPolicy(pool.RetryPolicy{Mode: pool.RetryFixed, MaxRetries: -1}).
+			Build()
+	})
+
+	testInvalidConfig(t, "exponential without initial delay", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetRetryPolicy(pool.RetryPolicy{Mode: pool.RetryExponential, MaxRetries: 3}).
+			Build()
+	})
+
+	testInvalidConfig(t, "jitter above 1", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetRetryPolicy(pool.RetryPolicy{Mode: pool.RetryFixed, MaxRetries: 3, InitialDelay: time.Millisecond, Jitter: 1.5}).
+			Build()
+	})
+
+	testInvalidConfig(t, "unknown mode", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetRetryPolicy(pool.RetryPolicy{Mode: pool.RetryMode(42)}).
+			Build()
+	})
+
+	testValidConfig(t, "no retries", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetRetryPolicy(pool.RetryPolicy{Mode: pool.RetryNone}).
+			Build()
+	})
+}
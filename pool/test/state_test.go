@@ -0,0 +1,132 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAndRestoreState(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder()))
+
+	objects := make([]*TestObject, 0, 40)
+	for range 40 {
+		obj, err := p.Get()
+		require.NoError(t, err)
+		objects = append(objects, obj)
+	}
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+
+	state := p.ExportState()
+	require.NoError(t, p.Close())
+
+	stats := p.GetPoolStatsSnapshot()
+	require.Positive(t, stats.TotalGrowthEvents, "the first pool had to grow")
+	assert.Equal(t, stats.CurrentCapacity, state.Capacity)
+	assert.Equal(t, stats.CurrentL1Capacity, state.L1Capacity)
+	assert.Equal(t, stats.TotalGrowthEvents, state.TotalGrowthEvents)
+	assert.Equal(t, stats.LastL1ResizeAtGrowthNum, state.LastL1ResizeAtGrowthNum)
+
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	var restored pool.PoolState
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, state, restored)
+
+	warm := createTestPool(t, buildTestConfig(t, newTestConfigBuilder().SetInitialState(restored)))
+	defer func() {
+		require.NoError(t, warm.Close())
+	}()
+
+	warmStats := warm.GetPoolStatsSnapshot()
+	assert.Equal(t, 16, warmStats.InitialCapacity)
+	assert.Equal(t, state.Capacity, warmStats.CurrentCapacity)
+	assert.Equal(t, state.L1Capacity, warmStats.CurrentL1Capacity)
+	assert.Equal(t, state.TotalGrowthEvents, warmStats.TotalGrowthEvents)
+	assert.Equal(t, state, warm.ExportState())
+
+	objects = objects[:0]
+	for range 40 {
+		obj, err := warm.Get()
+		require.NoError(t, err)
+		objects = append(objects, obj)
+	}
+	for _, obj := range objects {
+		require.NoError(t, warm.Put(obj))
+	}
+
+	assert.Equal(t, state.TotalGrowthEvents, warm.GetPoolStatsSnapshot().TotalGrowthEvents, "the warm pool skips the growth")
+}
+
+func TestRestoreStateClamping(t *testing.T) {
+	tests := []struct {
+		name               string
+		state              pool.PoolState
+		expectedCapacity   int
+		expectedL1Capacity int
+	}{
+		{name: "above hard limit", state: pool.PoolState{Capacity: 1000, L1Capacity: 1000}, expectedCapacity: 64, expectedL1Capacity: 64},
+		{name: "below min capacity", state: pool.PoolState{Capacity: 1, L1Capacity: 1}, expectedCapacity: 4, expectedL1Capacity: 1},
+		{name: "zero capacities", state: pool.PoolState{}, expectedCapacity: 16, expectedL1Capacity: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder().SetInitialState(tt.state)))
+			defer func() {
+				require.NoError(t, p.Close())
+			}()
+
+			stats := p.GetPoolStatsSnapshot()
+			assert.Equal(t, tt.expectedCapacity, stats.CurrentCapacity)
+			assert.Equal(t, tt.expectedL1Capacity, stats.CurrentL1Capacity)
+		})
+	}
+}
+
+func TestRestoreStateAtHardLimit(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder().SetInitialState(pool.PoolState{Capacity: 64})))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	objects := make([]*TestObject, 0, 64)
+	for range 64 {
+		obj, err := p.Get()
+		require.NoError(t, err)
+		objects = append(objects, obj)
+	}
+
+	_, ok := p.TryGet()
+	assert.False(t, ok)
+
+	stats := p.GetPoolStatsSnapshot()
+	assert.Equal(t, 64, stats.CurrentCapacity)
+	assert.Zero(t, stats.TotalGrowthEvents, "a pool restored at the hard limit doesn't grow")
+	assert.Empty(t, p.RecentCapacityEvents())
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestInitialStateConfig(t *testing.T) {
+	testInvalidConfig(t, "negative capacity", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetInitialState(pool.PoolState{Capacity: -1}).
+			Build()
+	})
+
+	testInvalidConfig(t, "negative counter", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetInitialState(pool.PoolState{TotalGrowthEvents: -1}).
+			Build()
+	})
+}
@@ -0,0 +1,134 @@
+package test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStatsSnapshotRace hammers Get, Put, growth and shrinking while taking snapshots, and
+// checks every snapshot is internally consistent. Run it with -race to catch unsynchronized reads.
+func TestStatsSnapshotRace(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(8).
+		SetHardLimit(256).
+		SetMinShrinkCapacity(4).
+		SetFastPathInitialSize(4).
+		SetFixedGrowthFactor(1.0).
+		SetFastPathGrowthEventsTrigger(1).
+		SetFastPathShrinkEventsTrigger(1).
+		SetRingBufferShrinkConfigs(time.Millisecond, time.Millisecond, 1, 4, 100, 90, 50).
+		Build()
+	require.NoError(t, err)
+
+	p := createTestPool(t, config)
+
+	const (
+		workers     = 8
+		snapshoters = 2
+		duration    = 300 * time.Millisecond
+	)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	var snapshots atomic.Int64
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			held := make([]*TestObject, 0, 16)
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					for _, obj := range held {
+						assert.NoError(t, p.Put(obj))
+					}
+					return
+				default:
+				}
+
+				// Alternate between bursts that force growth and releases that allow shrinking.
+				if obj, err := p.Get(); err == nil {
+					held = append(held, obj)
+				}
+
+				if len(held) == cap(held) || i%5 == 0 {
+					for _, obj := range held {
+						assert.NoError(t, p.Put(obj))
+					}
+					held = held[:0]
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			switch i % 3 {
+			case 0:
+				_ = p.ShrinkNow()
+			case 1:
+				_ = p.ResizeL1To(4 + i%8)
+			case 2:
+				_ = p.Prewarm(1)
+			}
+			time.Sleep(100 * time.Microsecond)
+		}
+	}()
+
+	for range snapshoters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				s := p.GetPoolStatsSnapshot()
+				snapshots.Add(1)
+
+				if err := pooltest.CheckSnapshotInvariants(s); err != nil {
+					t.Errorf("inconsistent snapshot: %v: %+v", err, *s)
+					return
+				}
+
+				if s.L1Length > s.CurrentL1Capacity {
+					t.Errorf("L1 length (%d) exceeds L1 capacity (%d)", s.L1Length, s.CurrentL1Capacity)
+					return
+				}
+			}
+		}()
+	}
+
+	time.Sleep(duration)
+	close(stop)
+	wg.Wait()
+
+	stats := p.GetPoolStatsSnapshot()
+	assert.Positive(t, snapshots.Load())
+	assert.Positive(t, stats.TotalGrowthEvents)
+	assert.Positive(t, stats.TotalShrinkEvents)
+	assert.Zero(t, stats.ObjectsInUse)
+	require.NoError(t, pooltest.CheckSnapshotInvariants(stats))
+
+	require.NoError(t, p.Close())
+}
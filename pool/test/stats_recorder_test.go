@@ -0,0 +1,186 @@
+package test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStatsSource returns whatever snapshot the test sets.
+type fakeStatsSource struct {
+	mu       sync.Mutex
+	snapshot pool.PoolStatsSnapshot
+}
+
+func (s *fakeStatsSource) set(snapshot pool.PoolStatsSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshot = snapshot
+}
+
+func (s *fakeStatsSource) GetPoolStatsSnapshot() *pool.PoolStatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := s.snapshot
+	return &snapshot
+}
+
+func newTestClock() *pooltest.FakeClock {
+	return pooltest.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestStatsRecorderRates(t *testing.T) {
+	clock := newTestClock()
+	source := &fakeStatsSource{}
+
+	recorder, err := pool.NewStatsRecorder(source, time.Second, 10, clock)
+	require.NoError(t, err)
+	defer recorder.Stop()
+
+	require.True(t, clock.WaitForTickers(1, time.Second))
+
+	source.set(pool.PoolStatsSnapshot{TotalGets: 100, FastReturnHit: 80, FastReturnMiss: 20, TotalGrowthEvents: 1})
+	clock.Advance(time.Second)
+	require.Eventually(t, func() bool { return recorder.Len() == 1 }, time.Second, time.Millisecond)
+
+	first, ok := recorder.Latest()
+	require.True(t, ok)
+	assert.Equal(t, pool.StatsRates{}, first.Rates, "the first sample has nothing to compare against")
+
+	source.set(pool.PoolStatsSnapshot{TotalGets: 600, FastReturnHit: 170, FastReturnMiss: 30, TotalGrowthEvents: 3, TotalShrinkEvents: 1})
+	clock.Advance(time.Second)
+	require.Eventually(t, func() bool { return recorder.Len() == 2 }, time.Second, time.Millisecond)
+
+	latest, ok := recorder.Latest()
+	require.True(t, ok)
+	assert.Equal(t, clock.Now(), latest.Time)
+	assert.Equal(t, time.Second, latest.Rates.Interval)
+	assert.InDelta(t, 500, latest.Rates.GetsPerSecond, 0.001)
+	assert.InDelta(t, 0.1, latest.Rates.SpillRate, 0.001)
+	assert.InDelta(t, 120, latest.Rates.GrowthEventsPerMinute, 0.001)
+	assert.InDelta(t, 60, latest.Rates.ShrinkEventsPerMinute, 0.001)
+}
+
+func TestStatsRecorderHistory(t *testing.T) {
+	clock := newTestClock()
+	source := &fakeStatsSource{}
+
+	// The interval never elapses, so only the manual samples are recorded.
+	recorder, err := pool.NewStatsRecorder(source, time.Hour, 3, clock)
+	require.NoError(t, err)
+	defer recorder.Stop()
+
+	_, ok := recorder.Latest()
+	assert.False(t, ok)
+	assert.Empty(t, recorder.Last(5))
+
+	_, ok = recorder.Rates(5)
+	assert.False(t, ok)
+
+	for i := 1; i <= 5; i++ {
+		source.set(pool.PoolStatsSnapshot{TotalGets: uint64(i * 10)})
+		recorder.Record()
+		clock.Advance(time.Second)
+	}
+
+	assert.Equal(t, 3, recorder.Len())
+
+	samples := recorder.Last(10)
+	require.Len(t, samples, 3)
+	for i, sample := range samples {
+		assert.Equal(t, uint64((i+3)*10), sample.Snapshot.TotalGets, "samples are ordered from oldest to newest")
+	}
+
+	samples = recorder.Last(2)
+	require.Len(t, samples, 2)
+	assert.Equal(t, uint64(40), samples[0].Snapshot.TotalGets)
+	assert.Equal(t, uint64(50), samples[1].Snapshot.TotalGets)
+
+	rates, ok := recorder.Rates(3)
+	require.True(t, ok)
+	assert.Equal(t, 2*time.Second, rates.Interval)
+	assert.InDelta(t, 10, rates.GetsPerSecond, 0.001)
+}
+
+func TestStatsRecorderCounterReset(t *testing.T) {
+	clock := newTestClock()
+	source := &fakeStatsSource{}
+
+	recorder, err := pool.NewStatsRecorder(source, time.Hour, 2, clock)
+	require.NoError(t, err)
+	defer recorder.Stop()
+
+	// A keyed pool that evicts a key loses its counters from the combined snapshot.
+	source.set(pool.PoolStatsSnapshot{TotalGets: 100, TotalGrowthEvents: 4})
+	recorder.Record()
+	clock.Advance(time.Second)
+
+	source.set(pool.PoolStatsSnapshot{TotalGets: 40, TotalGrowthEvents: 1})
+	sample := recorder.Record()
+
+	assert.Zero(t, sample.Rates.GetsPerSecond)
+	assert.Zero(t, sample.Rates.GrowthEventsPerMinute)
+}
+
+func TestStatsRecorderWithPool(t *testing.T) {
+	clock := newTestClock()
+
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(32).
+		SetHardLimit(64).
+		SetClock(clock).
+		Build()
+	require.NoError(t, err)
+
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	recorder, err := pool.NewStatsRecorder(p, time.Hour, 4, clock)
+	require.NoError(t, err)
+	defer recorder.Stop()
+
+	recorder.Record()
+
+	objects := make([]*TestObject, 4)
+	for i := range objects {
+		objects[i], err = p.Get()
+		require.NoError(t, err)
+	}
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+
+	clock.Advance(2 * time.Second)
+	sample := recorder.Record()
+
+	assert.Equal(t, uint64(4), sample.Snapshot.TotalGets)
+	assert.Equal(t, uint64(0), sample.Snapshot.ObjectsInUse)
+	assert.InDelta(t, 2, sample.Rates.GetsPerSecond, 0.001)
+}
+
+func TestStatsRecorderInvalidArguments(t *testing.T) {
+	source := &fakeStatsSource{}
+
+	_, err := pool.NewStatsRecorder(nil, time.Second, 1, nil)
+	assert.Error(t, err)
+
+	_, err = pool.NewStatsRecorder(source, 0, 1, nil)
+	assert.Error(t, err)
+
+	_, err = pool.NewStatsRecorder(source, time.Second, 0, nil)
+	assert.True(t, errors.Is(err, pool.ErrInvalidCapacity))
+
+	recorder, err := pool.NewStatsRecorder(source, time.Second, 1, nil)
+	require.NoError(t, err)
+	recorder.Stop()
+	recorder.Stop()
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryGet(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(4).
+		SetHardLimit(8).
+		SetMinShrinkCapacity(4).
+		SetFixedGrowthFactor(1.0).
+		SetFastPathInitialSize(2).
+		SetRingBufferBlocking(true).
+		Build()
+	require.NoError(t, err)
+
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	var objects []*TestObject
+	for range 8 {
+		obj, ok := p.TryGet()
+		require.True(t, ok)
+		require.NotNil(t, obj)
+		objects = append(objects, obj)
+	}
+
+	start := time.Now()
+	_, ok := p.TryGet()
+	assert.False(t, ok, "TryGet should fail once the hard limit is reached")
+	assert.Less(t, time.Since(start), 40*time.Millisecond, "TryGet must not wait")
+
+	stats := p.GetPoolStatsSnapshot()
+	assert.Equal(t, uint64(8), stats.ObjectsInUse)
+
+	require.NoError(t, p.Put(objects[0]))
+	obj, ok := p.TryGet()
+	require.True(t, ok)
+	objects[0] = obj
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestTryGetFromRingBuffer(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(8).
+		SetHardLimit(8).
+		SetMinShrinkCapacity(8).
+		SetFastPathInitialSize(1).
+		SetAllocationStrategy(100, 1).
+		SetRingBufferBlocking(true).
+		Build()
+	require.NoError(t, err)
+
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	objects := make([]*TestObject, 8)
+	for i := range objects {
+		var ok bool
+		objects[i], ok = p.TryGet()
+		require.True(t, ok)
+	}
+
+	stats := p.GetPoolStatsSnapshot()
+	assert.Equal(t, 8, stats.ObjectsCreated)
+
+	_, ok := p.TryGet()
+	assert.False(t, ok)
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
@@ -0,0 +1,155 @@
+package test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recommendation(t *testing.T, report pool.Report, setting string) pool.Recommendation {
+	t.Helper()
+	for _, rec := range report.Recommendations {
+		if rec.Setting == setting {
+			assert.NotEmpty(t, rec.Reason)
+			return rec
+		}
+	}
+	t.Fatalf("no recommendation for %s", setting)
+	return pool.Recommendation{}
+}
+
+func TestRecommendEmptyHistory(t *testing.T) {
+	config, report, err := pool.Recommend[*TestObject](nil, pool.TuningGoals{})
+	require.NoError(t, err)
+	require.NotNil(t, config)
+
+	defaults, err := pool.NewPoolConfigBuilder[*TestObject]().Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, defaults.GetInitialCapacity(), config.GetInitialCapacity())
+	assert.Equal(t, defaults.GetHardLimit(), config.GetHardLimit())
+	assert.Empty(t, report.Recommendations)
+}
+
+func TestRecommendGrowingPool(t *testing.T) {
+	history := []pool.PoolStatsSnapshot{
+		{CurrentCapacity: 64, HardLimit: 200, ObjectsInUse: 40, CurrentL1Capacity: 64, TotalGrowthEvents: 0},
+		{CurrentCapacity: 96, HardLimit: 200, ObjectsInUse: 90, CurrentL1Capacity: 64, TotalGrowthEvents: 1},
+		{CurrentCapacity: 144, HardLimit: 200, ObjectsInUse: 190, CurrentL1Capacity: 64, TotalGrowthEvents: 3, L2SpillRate: 0.4},
+	}
+
+	config, report, err := pool.Recommend[*TestObject](history, pool.TuningGoals{Headroom: 0.5})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(190), report.PeakInUse)
+	assert.Equal(t, 3, report.GrowthEvents)
+
+	assert.Equal(t, 285, config.GetInitialCapacity())
+	assert.Contains(t, recommendation(t, report, "initialCapacity").Reason, "grew 3 times")
+
+	assert.Equal(t, 570, config.GetHardLimit())
+	assert.Contains(t, recommendation(t, report, "hardLimit").Reason, "hard limit of 200")
+
+	assert.Equal(t, 285, config.GetFastPath().GetInitialSize())
+	assert.Greater(t, config.GetFastPath().GetRefillPercent(), 20)
+	recommendation(t, report, "fastPath.refillPercent")
+
+	assert.Equal(t, pool.AggressivenessBalanced, config.GetShrink().GetAggressivenessLevel())
+	assert.Equal(t, "balanced", recommendation(t, report, "shrinkAggressiveness").Value)
+}
+
+func TestRecommendOscillatingPool(t *testing.T) {
+	history := []pool.PoolStatsSnapshot{
+		{CurrentCapacity: 64, ObjectsInUse: 60, HardLimit: 1000},
+		{CurrentCapacity: 128, ObjectsInUse: 100, HardLimit: 1000, TotalGrowthEvents: 1},
+		{CurrentCapacity: 64, ObjectsInUse: 10, HardLimit: 1000, TotalGrowthEvents: 1, TotalShrinkEvents: 1},
+		{CurrentCapacity: 128, ObjectsInUse: 110, HardLimit: 1000, TotalGrowthEvents: 2, TotalShrinkEvents: 1},
+	}
+
+	config, report, err := pool.Recommend[*TestObject](history, pool.TuningGoals{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.CapacityReversals)
+	assert.Equal(t, pool.AggressivenessConservative, config.GetShrink().GetAggressivenessLevel())
+	assert.Contains(t, recommendation(t, report, "shrinkAggressiveness").Reason, "changed direction 2 times")
+	assert.Equal(t, 20, config.GetFastPath().GetRefillPercent())
+}
+
+func TestRecommendOversizedPool(t *testing.T) {
+	history := []pool.PoolStatsSnapshot{
+		{CurrentCapacity: 1000, ObjectsInUse: 10, HardLimit: 5000, CurrentL1Capacity: 64},
+		{CurrentCapacity: 1000, ObjectsInUse: 20, HardLimit: 5000, CurrentL1Capacity: 64},
+	}
+
+	config, report, err := pool.Recommend[*TestObject](history, pool.TuningGoals{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 25, config.GetInitialCapacity())
+	assert.Equal(t, 50, config.GetHardLimit())
+	assert.Equal(t, 25, config.GetFastPath().GetInitialSize())
+	assert.Equal(t, pool.AggressivenessAggressive, config.GetShrink().GetAggressivenessLevel())
+	assert.Contains(t, report.String(), "initialCapacity = 25")
+}
+
+func TestRecommendOutOfRangeHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		history []pool.PoolStatsSnapshot
+		goals   pool.TuningGoals
+	}{
+		{name: "huge peak in use", history: []pool.PoolStatsSnapshot{{CurrentCapacity: 64, HardLimit: 128, ObjectsInUse: math.MaxUint64}}},
+		{name: "huge hard limit", history: []pool.PoolStatsSnapshot{{CurrentCapacity: 64, HardLimit: math.MaxInt, ObjectsInUse: 10}}},
+		{name: "huge headroom", history: []pool.PoolStatsSnapshot{{CurrentCapacity: 64, HardLimit: 128, ObjectsInUse: 10}}, goals: pool.TuningGoals{Headroom: math.MaxFloat64}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config *pool.PoolConfig[*TestObject]
+			var err error
+			require.NotPanics(t, func() {
+				config, _, err = pool.Recommend[*TestObject](tt.history, tt.goals)
+			})
+			assert.Error(t, err)
+			assert.Nil(t, config)
+		})
+	}
+}
+
+func TestRecommendFromRunningPool(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().Build()
+	require.NoError(t, err)
+
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	var history []pool.PoolStatsSnapshot
+	var objects []*TestObject
+	for range 5 {
+		for range 30 {
+			obj, err := p.Get()
+			require.NoError(t, err)
+			objects = append(objects, obj)
+		}
+		history = append(history, *p.GetPoolStatsSnapshot())
+	}
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+	history = append(history, *p.GetPoolStatsSnapshot())
+
+	tunedConfig, report, err := pool.Recommend[*TestObject](history, pool.TuningGoals{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(150), report.PeakInUse)
+	assert.GreaterOrEqual(t, tunedConfig.GetInitialCapacity(), 150)
+	assert.Len(t, report.Recommendations, 5)
+
+	tuned := createTestPool(t, tunedConfig)
+	require.NoError(t, tuned.Close())
+}
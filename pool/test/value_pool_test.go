@@ -0,0 +1,137 @@
+package test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type point struct {
+	X, Y int
+}
+
+func TestValuePoolByteSlices(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[[]byte]().
+		SetInitialCapacity(8).
+		SetHardLimit(16).
+		SetMinShrinkCapacity(8).
+		Build()
+	require.NoError(t, err)
+
+	p, err := pool.NewValuePool(config,
+		func() []byte { return make([]byte, 0, 64) },
+		func(b []byte) []byte { return b[:0] },
+	)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	buf, err := p.Get()
+	require.NoError(t, err)
+	assert.Equal(t, 64, cap(buf))
+
+	buf = append(buf, "hello"...)
+	require.NoError(t, p.Put(buf))
+
+	for range 16 {
+		buf, err = p.Get()
+		require.NoError(t, err)
+		assert.Empty(t, buf)
+		require.NoError(t, p.Put(buf))
+	}
+}
+
+func TestValuePoolStructs(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[point]().
+		SetInitialCapacity(4).
+		SetHardLimit(8).
+		SetMinShrinkCapacity(4).
+		Build()
+	require.NoError(t, err)
+
+	p, err := pool.NewValuePool(config,
+		func() point { return point{} },
+		func(pt point) point { return point{} },
+	)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	objects := make([]point, 8)
+	for i := range objects {
+		objects[i], err = p.Get()
+		require.NoError(t, err)
+		assert.Equal(t, point{}, objects[i])
+		objects[i].X = i
+	}
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+
+	obj, err := p.Get()
+	require.NoError(t, err)
+	assert.Equal(t, point{}, obj)
+	require.NoError(t, p.Put(obj))
+}
+
+func TestValuePoolInterfaces(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[io.ReadWriter]().
+		SetInitialCapacity(4).
+		SetHardLimit(8).
+		SetMinShrinkCapacity(4).
+		Build()
+	require.NoError(t, err)
+
+	p, err := pool.NewValuePool(config,
+		func() io.ReadWriter { return &bytes.Buffer{} },
+		func(rw io.ReadWriter) io.ReadWriter {
+			rw.(*bytes.Buffer).Reset()
+			return rw
+		},
+	)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	rw, err := p.Get()
+	require.NoError(t, err)
+	_, err = rw.Write([]byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, p.Put(rw))
+}
+
+func TestValuePoolValidation(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[io.ReadWriter]().Build()
+	require.NoError(t, err)
+
+	_, err = pool.NewValuePool(config,
+		func() io.ReadWriter { return nil },
+		func(rw io.ReadWriter) io.ReadWriter { return rw },
+	)
+	assert.Error(t, err)
+
+	_, err = pool.NewValuePool(config,
+		func() io.ReadWriter { return &bytes.Buffer{} },
+		nil,
+	)
+	assert.Error(t, err)
+
+	pointConfig, err := pool.NewPoolConfigBuilder[point]().Build()
+	require.NoError(t, err)
+
+	_, err = pool.NewPool(pointConfig,
+		func() point { return point{} },
+		func(point) {},
+		nil,
+	)
+	assert.Error(t, err, "NewPool keeps requiring pointer types")
+}
@@ -0,0 +1,358 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startWaiter queues a GetWithPriority call and waits until it's in the queue.
+// The served object is sent on served with its Value set to id.
+func startWaiter(t *testing.T, p *pool.Pool[*TestObject], priority, id int, served chan<- *TestObject) {
+	queued := p.Waiters()
+	go func() {
+		obj, err := p.GetWithPriority(context.Background(), priority)
+		if err == nil {
+			obj.Value = id
+			served <- obj
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		return p.Waiters() == queued+1
+	}, time.Second, time.Millisecond)
+}
+
+func TestWaitQueueFIFO(t *testing.T) {
+	config := buildTestConfig(t, newTestConfigBuilder().SetInitialCapacity(4).SetHardLimit(4).SetFastPathInitialSize(2).SetWaitQueueConfigs(0))
+	p := createTestPool(t, config)
+	objects := getObjects(t, p, 4)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	served := make(chan *TestObject, 3)
+	for id := 1; id <= 3; id++ {
+		startWaiter(t, p, 0, id, served)
+	}
+
+	for i := range 3 {
+		require.NoError(t, p.Put(objects[i]))
+		objects[i] = <-served
+		assert.Equal(t, i+1, objects[i].Value)
+	}
+
+	assert.Equal(t, 0, p.Waiters())
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestWaitQueuePriority(t *testing.T) {
+	config := buildTestConfig(t, newTestConfigBuilder().SetInitialCapacity(4).SetHardLimit(4).SetFastPathInitialSize(2).SetWaitQueueConfigs(0))
+	p := createTestPool(t, config)
+	objects := getObjects(t, p, 4)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	served := make(chan *TestObject, 3)
+	startWaiter(t, p, 0, 1, served)
+	startWaiter(t, p, 0, 2, served)
+	startWaiter(t, p, 10, 3, served)
+
+	expected := []int{3, 1, 2}
+	for i, id := range expected {
+		require.NoError(t, p.Put(objects[i]))
+		objects[i] = <-served
+		assert.Equal(t, id, objects[i].Value)
+	}
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestWaitQueueMaxWaiters(t *testing.T) {
+	config := buildTestConfig(t, newTestConfigBuilder().SetInitialCapacity(4).SetHardLimit(4).SetFastPathInitialSize(2).SetWaitQueueConfigs(2))
+	p := createTestPool(t, config)
+	objects := getObjects(t, p, 4)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	served := make(chan *TestObject, 2)
+	startWaiter(t, p, 0, 1, served)
+	startWaiter(t, p, 0, 2, served)
+
+	_, err := p.GetWithPriority(context.Background(), 100)
+	assert.ErrorIs(t, err, pool.ErrTooManyWaiters)
+
+	for i := range 2 {
+		require.NoError(t, p.Put(objects[i]))
+		objects[i] = <-served
+	}
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestWaitQueueContextCancel(t *testing.T) {
+	config := buildTestConfig(t, newTestConfigBuilder().SetInitialCapacity(4).SetHardLimit(4).SetFastPathInitialSize(2).SetWaitQueueConfigs(0))
+	p := createTestPool(t, config)
+	objects := getObjects(t, p, 4)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	_, err := p.GetWithPriority(ctx, 0)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, p.Waiters())
+
+	require.NoError(t, p.Put(objects[0]))
+	obj, err := p.GetWithPriority(context.Background(), 0)
+	require.NoError(t, err)
+	objects[0] = obj
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestWaitQueueGet(t *testing.T) {
+	config := buildTestConfig(t, newTestConfigBuilder().SetInitialCapacity(4).SetHardLimit(4).SetFastPathInitialSize(2).SetWaitQueueConfigs(0))
+	p := createTestPool(t, config)
+	objects := getObjects(t, p, 4)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	done := make(chan *TestObject)
+	go func() {
+		obj, err := p.Get()
+		if err == nil {
+			done <- obj
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		return p.Waiters() == 1
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, p.Put(objects[0]))
+
+	select {
+	case objects[0] = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Get was not served by Put")
+	}
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestWaitQueueTimeoutWithFakeClock(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(2).
+		SetHardLimit(2).
+		SetMinShrinkCapacity(2).
+		SetFastPathInitialSize(1).
+		SetRingBufferBlocking(true).
+		SetRingBufferReadTimeout(time.Second).
+		SetWaitQueueConfigs(0).
+		SetClock(clock).
+		Build()
+	require.NoError(t, err)
+
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	objects := make([]*TestObject, 2)
+	for i := range objects {
+		objects[i], err = p.Get()
+		require.NoError(t, err)
+	}
+
+	// The shrink ticker is already running, the waiter adds its timer.
+	require.True(t, clock.WaitForTickers(1, time.Second))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.Get()
+		done <- err
+	}()
+	require.True(t, clock.WaitForTickers(2, time.Second))
+
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case err := <-done:
+		t.Fatalf("Get returned before its timeout: %v", err)
+	default:
+	}
+
+	clock.Advance(time.Millisecond)
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Get didn't time out on the pool clock")
+	}
+	assert.Equal(t, 0, p.Waiters())
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestWaitQueueServedByReclaim(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	config, err := pool.NewPoolConfigBuilder[*TestObject]().
+		SetInitialCapacity(2).
+		SetHardLimit(2).
+		SetMinShrinkCapacity(2).
+		SetFastPathInitialSize(1).
+		SetRingBufferBlocking(true).
+		SetWaitQueueConfigs(0).
+		SetHoldTimeoutConfigs(100*time.Millisecond, 50*time.Millisecond, true, nil).
+		SetClock(clock).
+		Build()
+	require.NoError(t, err)
+
+	p := createTestPool(t, config)
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+	require.True(t, clock.WaitForTickers(2, time.Second))
+
+	objects := make([]*TestObject, 2)
+	for i := range objects {
+		objects[i], err = p.Get()
+		require.NoError(t, err)
+	}
+
+	served := make(chan *TestObject, 1)
+	startWaiter(t, p, 0, 1, served)
+
+	// The replacements of the reclaimed objects aren't handed over by Put, the waiter is woken for them.
+	for range 3 {
+		clock.Advance(50 * time.Millisecond)
+	}
+
+	select {
+	case obj := <-served:
+		require.NoError(t, p.Put(obj))
+	case <-time.After(time.Second):
+		t.Fatal("the waiter wasn't woken by the reclaim")
+	}
+
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestWaitQueueServedAfterPoison(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder().
+		SetInitialCapacity(4).
+		SetHardLimit(4).
+		SetMinShrinkCapacity(4).
+		SetFastPathInitialSize(2).
+		SetWaitQueueConfigs(0).
+		SetHandleDebugConfigs(func(obj *TestObject) {
+			obj.Value = -1
+		})))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	handles := make([]*pool.Handle[*TestObject], 4)
+	for i := range handles {
+		h, err := p.GetHandle()
+		require.NoError(t, err)
+		handles[i] = h
+	}
+
+	served := make(chan *TestObject, 1)
+	startWaiter(t, p, 0, 1, served)
+
+	// The poisoned object is dropped instead of handed over, the waiter is woken to replace it.
+	require.NoError(t, handles[0].Release())
+
+	select {
+	case obj := <-served:
+		require.NoError(t, p.Put(obj))
+	case <-time.After(time.Second):
+		t.Fatal("the waiter wasn't woken by the poison")
+	}
+
+	for _, h := range handles[1:] {
+		require.NoError(t, h.Release())
+	}
+}
+
+func TestWaitQueueAtHardLimit(t *testing.T) {
+	p := createTestPool(t, buildTestConfig(t, newTestConfigBuilder().
+		SetHardLimit(32).
+		SetWaitQueueConfigs(0)))
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	getAll := func() []*TestObject {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		objects := make([]*TestObject, 32)
+		for i := range objects {
+			obj, err := p.GetWithPriority(ctx, 0)
+			require.NoError(t, err)
+			objects[i] = obj
+		}
+		return objects
+	}
+
+	// Growing to the hard limit blocks further growth.
+	objects := getAll()
+	assert.Equal(t, 32, p.GetPoolStatsSnapshot().CurrentCapacity)
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+
+	// Most returns spilled to the ring buffer, they're taken from there instead of waiting.
+	objects = getAll()
+	for _, obj := range objects {
+		require.NoError(t, p.Put(obj))
+	}
+}
+
+func TestWaitQueueConfigurations(t *testing.T) {
+	testInvalidConfig(t, "negative max waiters", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetRingBufferBlocking(true).
+			SetWaitQueueConfigs(-1).
+			Build()
+	})
+
+	testValidConfig(t, "non-blocking ring buffer", func() (*pool.PoolConfig[*TestObject], error) {
+		return pool.NewPoolConfigBuilder[*TestObject]().
+			SetRingBufferBlocking(false).
+			SetWaitQueueConfigs(10).
+			Build()
+	})
+}
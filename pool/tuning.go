@@ -0,0 +1,279 @@
+package pool
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	defaultTuningHeadroom     = 0.25
+	defaultTuningMaxSpillRate = 0.10
+
+	// hardLimitPressure is the fraction of the hard limit that, once reached by the observed
+	// peak, is taken as a sign that the limit throttled the workload.
+	hardLimitPressure = 0.9
+
+	// spillRefillPercent is the fast path refill threshold recommended when returns spill to
+	// the ring buffer, so the spilled objects are pulled back into L1 before it runs dry.
+	spillRefillPercent = 50
+
+	// lowUtilization is the peak in use to capacity ratio under which the pool is considered
+	// oversized for its workload.
+	lowUtilization = 0.5
+
+	// maxRecommendedCapacity bounds the capacities Recommend sizes from a history, so doubling
+	// them for the hard limit can't overflow.
+	maxRecommendedCapacity = math.MaxInt / 4
+)
+
+var errHistoryOutOfRange = errors.New("history is out of the range a pool can be sized from")
+
+// TuningGoals describes what Recommend should optimize for.
+// Zero values are replaced with defaults.
+type TuningGoals struct {
+	// Headroom is the fraction of extra capacity kept above the observed peak in use, 0.25 by default.
+	Headroom float64
+
+	// MaxSpillRate is the highest acceptable L2 spill rate before the fast path is enlarged, 0.10 by default.
+	MaxSpillRate float64
+
+	// PreferMemory favors releasing idle objects over keeping them around for reuse.
+	PreferMemory bool
+}
+
+// Recommendation is a single suggested setting and the reason behind it.
+type Recommendation struct {
+	Setting string
+	Value   string
+	Reason  string
+}
+
+// Report explains the configuration returned by Recommend.
+type Report struct {
+	// Samples is the number of snapshots the recommendations are based on.
+	Samples int
+
+	// PeakInUse is the highest number of objects in use across the snapshots.
+	PeakInUse uint64
+
+	// GrowthEvents and ShrinkEvents are the events observed between the first and last snapshot.
+	GrowthEvents int
+	ShrinkEvents int
+
+	// CapacityReversals counts how often the capacity changed direction, growing after a
+	// shrink or shrinking after a growth.
+	CapacityReversals int
+
+	// SpillRate is the L2 spill rate of the last snapshot.
+	SpillRate float64
+
+	Recommendations []Recommendation
+}
+
+// String formats the report as one line per recommendation.
+func (r Report) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "based on %d snapshots: peak in use %d, %d growth events, %d shrink events, %d capacity reversals, L2 spill rate %.2f%%\n",
+		r.Samples, r.PeakInUse, r.GrowthEvents, r.ShrinkEvents, r.CapacityReversals, r.SpillRate*100)
+
+	for _, rec := range r.Recommendations {
+		fmt.Fprintf(&sb, "  %s = %s: %s\n", rec.Setting, rec.Value, rec.Reason)
+	}
+
+	return sb.String()
+}
+
+// Recommend suggests a pool configuration from a history of statistics snapshots, ordered
+// from oldest to newest, taken from a pool running a representative workload.
+// It sizes the initial capacity, hard limit and fast path from the peak in use, the refill
+// threshold from the L2 spill rate, and the shrink aggressiveness from how often the
+// capacity grew and shrank. The report explains each choice.
+// With an empty history the default configuration is returned. An error is returned when the
+// history or goals lead to capacities too large to configure, e.g. a corrupted snapshot.
+func Recommend[T any](history []PoolStatsSnapshot, goals TuningGoals) (*PoolConfig[T], Report, error) {
+	goals = goals.withDefaults()
+
+	report := Report{Samples: len(history)}
+	if len(history) == 0 {
+		return buildRecommended(NewPoolConfigBuilder[T](), report)
+	}
+
+	first, last := history[0], history[len(history)-1]
+	for _, s := range history {
+		report.PeakInUse = max(report.PeakInUse, s.ObjectsInUse)
+	}
+	report.GrowthEvents = max(last.TotalGrowthEvents-first.TotalGrowthEvents, 0)
+	report.ShrinkEvents = max(last.TotalShrinkEvents-first.TotalShrinkEvents, 0)
+	report.CapacityReversals = capacityReversals(history)
+	report.SpillRate = last.L2SpillRate
+
+	if capacity := float64(report.PeakInUse) * (1 + goals.Headroom); !(capacity <= maxRecommendedCapacity) || last.HardLimit > maxRecommendedCapacity {
+		return nil, report, fmt.Errorf("%w: peak in use %d with %v headroom, hard limit %d",
+			errHistoryOutOfRange, report.PeakInUse, goals.Headroom, last.HardLimit)
+	}
+
+	initialCapacity := report.recommendInitialCapacity(goals)
+	hardLimit := report.recommendHardLimit(last, initialCapacity)
+	fastPathSize := report.recommendFastPathSize(last, initialCapacity, goals)
+	refillPercent := report.recommendRefillPercent(goals)
+	level := report.recommendShrinkAggressiveness(last, goals)
+
+	// The aggressiveness level resets the minimum capacities, so it is applied first.
+	builder, _ := NewPoolConfigBuilder[T]().SetShrinkAggressiveness(level)
+	builder.SetInitialCapacity(initialCapacity).
+		SetHardLimit(hardLimit).
+		SetMinShrinkCapacity(min(initialCapacity, defaultMinCapacity)).
+		SetFastPathInitialSize(fastPathSize).
+		SetFastPathShrinkMinCapacity(fastPathSize).
+		SetFastPathRefillPercent(refillPercent)
+
+	return buildRecommended(builder, report)
+}
+
+func (g TuningGoals) withDefaults() TuningGoals {
+	if g.Headroom <= 0 {
+		g.Headroom = defaultTuningHeadroom
+	}
+
+	if g.MaxSpillRate <= 0 {
+		g.MaxSpillRate = defaultTuningMaxSpillRate
+	}
+
+	return g
+}
+
+func (r *Report) add(setting string, value any, reason string, args ...any) {
+	r.Recommendations = append(r.Recommendations, Recommendation{
+		Setting: setting,
+		Value:   fmt.Sprint(value),
+		Reason:  fmt.Sprintf(reason, args...),
+	})
+}
+
+func (r *Report) recommendInitialCapacity(goals TuningGoals) int {
+	capacity := max(int(math.Ceil(float64(r.PeakInUse)*(1+goals.Headroom))), 1)
+
+	if r.GrowthEvents > 0 {
+		r.add("initialCapacity", capacity,
+			"the pool grew %d times to reach a peak of %d objects in use, starting at the peak plus %.0f%% headroom avoids growing under load",
+			r.GrowthEvents, r.PeakInUse, goals.Headroom*100)
+	} else {
+		r.add("initialCapacity", capacity,
+			"the peak in use was %d objects, plus %.0f%% headroom",
+			r.PeakInUse, goals.Headroom*100)
+	}
+
+	return capacity
+}
+
+func (r *Report) recommendHardLimit(last PoolStatsSnapshot, initialCapacity int) int {
+	limit := initialCapacity * 2
+
+	if last.HardLimit > 0 && float64(r.PeakInUse) >= float64(last.HardLimit)*hardLimitPressure {
+		limit = max(limit, last.HardLimit*2)
+		r.add("hardLimit", limit,
+			"the peak in use of %d came within %.0f%% of the hard limit of %d, so the limit was likely throttling the workload",
+			r.PeakInUse, hardLimitPressure*100, last.HardLimit)
+		return limit
+	}
+
+	r.add("hardLimit", limit,
+		"twice the initial capacity leaves room for bursts above the observed peak while still bounding memory")
+	return limit
+}
+
+func (r *Report) recommendFastPathSize(last PoolStatsSnapshot, initialCapacity int, goals TuningGoals) int {
+	if r.SpillRate > goals.MaxSpillRate {
+		size := initialCapacity
+		r.add("fastPath.initialSize", size,
+			"%.2f%% of returns spilled past the fast path, above the %.2f%% goal, so the fast path is sized to hold every object",
+			r.SpillRate*100, goals.MaxSpillRate*100)
+		return size
+	}
+
+	size := max(min(int(r.PeakInUse), initialCapacity), 1)
+	if last.CurrentL1Capacity > 0 {
+		size = max(min(last.CurrentL1Capacity, initialCapacity), 1)
+	}
+
+	r.add("fastPath.initialSize", size,
+		"the spill rate of %.2f%% is within the %.2f%% goal, so the fast path keeps its observed size, bounded by the initial capacity",
+		r.SpillRate*100, goals.MaxSpillRate*100)
+	return size
+}
+
+func (r *Report) recommendRefillPercent(goals TuningGoals) int {
+	if r.SpillRate > goals.MaxSpillRate {
+		r.add("fastPath.refillPercent", spillRefillPercent,
+			"objects that spill to the ring buffer are pulled back into the fast path earlier, before it runs dry")
+		return spillRefillPercent
+	}
+
+	r.add("fastPath.refillPercent", defaultRefillPercent,
+		"the fast path absorbs most returns, so the default refill threshold is enough")
+	return defaultRefillPercent
+}
+
+func (r *Report) recommendShrinkAggressiveness(last PoolStatsSnapshot, goals TuningGoals) AggressivenessLevel {
+	oscillating := r.CapacityReversals >= 2 || (r.GrowthEvents > 0 && r.ShrinkEvents > 0)
+	if oscillating {
+		r.add("shrinkAggressiveness", "conservative",
+			"the capacity changed direction %d times with %d growth and %d shrink events, shrinking less keeps the pool from regrowing what it just released",
+			r.CapacityReversals, r.GrowthEvents, r.ShrinkEvents)
+		return AggressivenessConservative
+	}
+
+	underutilized := last.CurrentCapacity > 0 && float64(r.PeakInUse) < float64(last.CurrentCapacity)*lowUtilization
+	if underutilized {
+		r.add("shrinkAggressiveness", "aggressive",
+			"the peak in use of %d is low for a capacity of %d and the capacity never oscillated, so idle objects can be released sooner",
+			r.PeakInUse, last.CurrentCapacity)
+		return AggressivenessAggressive
+	}
+
+	if goals.PreferMemory {
+		r.add("shrinkAggressiveness", "aggressive",
+			"the capacity never oscillated and memory is preferred over reuse, so idle objects can be released sooner")
+		return AggressivenessAggressive
+	}
+
+	r.add("shrinkAggressiveness", "balanced",
+		"the capacity was stable and well utilized, so the balanced default is kept")
+	return AggressivenessBalanced
+}
+
+// capacityReversals counts how often the capacity changed direction across the history.
+func capacityReversals(history []PoolStatsSnapshot) int {
+	reversals := 0
+	direction := 0
+
+	for i := 1; i < len(history); i++ {
+		delta := history[i].CurrentCapacity - history[i-1].CurrentCapacity
+		if delta == 0 {
+			continue
+		}
+
+		current := 1
+		if delta < 0 {
+			current = -1
+		}
+
+		if direction != 0 && current != direction {
+			reversals++
+		}
+		direction = current
+	}
+
+	return reversals
+}
+
+// buildRecommended builds the configuration assembled by Recommend along with its report.
+func buildRecommended[T any](builder PoolConfigBuilder[T], report Report) (*PoolConfig[T], Report, error) {
+	config, err := builder.Build()
+	if err != nil {
+		return nil, report, fmt.Errorf("recommended configuration is invalid: %w", err)
+	}
+	return config, report, nil
+}
@@ -0,0 +1,65 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+)
+
+// NewValuePool creates a new object pool for types that aren't pointers, such as []byte,
+// small structs held by value, or interface types like io.ReadWriteCloser.
+//
+// Copy semantics: Get returns a copy of the stored value and Put stores a copy of the value
+// it receives. Changes to a struct held by value are only seen by the pool once it's put back,
+// while reference types like slices, maps and interface values share their underlying data
+// with the copy kept by the pool, so they must not be used after Put.
+//
+// The allocator function creates a new value. Zero values are valid objects, except nil
+// interface values and nil pointers, maps, channels or functions, which fail the allocation.
+//
+// The cleaner function receives a value and returns its cleaned version, which is the one stored
+// in the pool, e.g. func(b []byte) []byte { return b[:0] }.
+func NewValuePool[T any](config *PoolConfig[T], allocator func() T, cleaner func(T) T) (PoolObj[T], error) {
+	if allocator == nil {
+		return nil, errNilAllocator
+	}
+
+	if cleaner == nil {
+		return nil, errNilCleaner
+	}
+
+	if config != nil && config.objectTracking != nil && config.objectTracking.enabled {
+		return nil, errTrackingRequiresPointers
+	}
+
+	fallibleAllocator := func(context.Context) (T, error) {
+		return allocator(), nil
+	}
+
+	validateObject := func(obj T) error {
+		return validateValue(obj)
+	}
+
+	return newPool(config, fallibleAllocator, cleaner, nil, validateObject)
+}
+
+// validateValue validates a value returned by the allocator of a value pool.
+func validateValue[T any](obj T) error {
+	if isNilObject(obj) {
+		return fmt.Errorf("%w: allocator returned a nil value of type %T", errNilObject, obj)
+	}
+
+	return nil
+}
+
+// inPlaceCleaner adapts a cleaner that mutates pointer objects in place to the
+// cleaner signature used by the pool.
+func inPlaceCleaner[T any](cleaner func(T)) func(T) T {
+	if cleaner == nil {
+		return nil
+	}
+
+	return func(obj T) T {
+		cleaner(obj)
+		return obj
+	}
+}
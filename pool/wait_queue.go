@@ -0,0 +1,331 @@
+package pool
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// waiter is a caller queued for an object. The object is handed over through ch.
+type waiter[T any] struct {
+	ch       chan T
+	priority int
+
+	// wake asks the waiter to try to get an object on its own, see waitQueue.wakeHead.
+	wake chan struct{}
+
+	// seq orders waiters of the same priority by arrival.
+	seq uint64
+
+	// index is the waiter's position in the heap, -1 once it left the queue.
+	index int
+}
+
+// waiterHeap orders waiters by descending priority, then by arrival.
+type waiterHeap[T any] []*waiter[T]
+
+func (h waiterHeap[T]) Len() int { return len(h) }
+
+func (h waiterHeap[T]) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h waiterHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *waiterHeap[T]) Push(x any) {
+	w := x.(*waiter[T])
+	w.index = len(*h)
+	*h = append(*h, w)
+}
+
+func (h *waiterHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	w.index = -1
+	*h = old[:n-1]
+	return w
+}
+
+// waitQueue holds the callers waiting for an object, so returned objects are handed to them
+// in priority and arrival order instead of whichever blocked reader wakes up first.
+type waitQueue[T any] struct {
+	mu      sync.Mutex
+	waiters waiterHeap[T]
+	nextSeq uint64
+
+	// length mirrors len(waiters) so Put can skip the lock when nobody is waiting.
+	length atomic.Int64
+
+	// maxWaiters is the maximum number of queued callers, zero means no limit.
+	maxWaiters int
+}
+
+func newWaitQueue[T any](maxWaiters int) *waitQueue[T] {
+	return &waitQueue[T]{maxWaiters: maxWaiters}
+}
+
+// enqueue adds a waiter with the given priority, failing with ErrTooManyWaiters if the queue is full.
+func (q *waitQueue[T]) enqueue(priority int) (*waiter[T], error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.maxWaiters > 0 && len(q.waiters) >= q.maxWaiters {
+		return nil, fmt.Errorf("%w: %d callers already waiting", ErrTooManyWaiters, len(q.waiters))
+	}
+
+	w := &waiter[T]{
+		ch:       make(chan T, 1),
+		wake:     make(chan struct{}, 1),
+		priority: priority,
+		seq:      q.nextSeq,
+	}
+	q.nextSeq++
+
+	heap.Push(&q.waiters, w)
+	q.length.Store(int64(len(q.waiters)))
+
+	return w, nil
+}
+
+// handOff gives obj to the first waiter, returning false if nobody is waiting.
+func (q *waitQueue[T]) handOff(obj T) bool {
+	if q.length.Load() == 0 {
+		return false
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.waiters) == 0 {
+		return false
+	}
+
+	w := heap.Pop(&q.waiters).(*waiter[T])
+	q.length.Store(int64(len(q.waiters)))
+
+	w.ch <- obj
+	return true
+}
+
+// remove takes w out of the queue, returning false if it already left it because an
+// object was handed to it. The next waiter is woken in case w left an object behind.
+func (q *waitQueue[T]) remove(w *waiter[T]) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if w.index < 0 {
+		return false
+	}
+
+	heap.Remove(&q.waiters, w.index)
+	q.length.Store(int64(len(q.waiters)))
+	q.lockedWakeHead()
+
+	return true
+}
+
+// wakeHead wakes the waiter at the head of the queue, so it tries to get an object on its own.
+// It's called whenever an object may have become available without a handOff, e.g. a Put that
+// raced with the waiter's enqueue, a background fill or a retired object making room to allocate.
+func (q *waitQueue[T]) wakeHead() {
+	if q.length.Load() == 0 {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.lockedWakeHead()
+}
+
+// lockedWakeHead wakes the waiter at the head of the queue. Must be called with q.mu held.
+func (q *waitQueue[T]) lockedWakeHead() {
+	if len(q.waiters) == 0 {
+		return
+	}
+
+	select {
+	case q.waiters[0].wake <- struct{}{}:
+	default:
+	}
+}
+
+// isHead reports whether w is the next waiter to be served.
+func (q *waitQueue[T]) isHead(w *waiter[T]) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.waiters) > 0 && q.waiters[0] == w
+}
+
+// GetWithPriority returns an object from the pool, waiting in the pool's queue until one is
+// available or ctx is done. Waiters with a higher priority are served first, and waiters with
+// the same priority in arrival order.
+//
+// Returns ErrTooManyWaiters if the queue is full, and ctx's error if it's done before an
+// object becomes available.
+func (p *Pool[T]) GetWithPriority(ctx context.Context, priority int) (zero T, err error) {
+	obj, err := p.getWithPriority(ctx, priority)
+	if err != nil {
+		return zero, err
+	}
+
+	p.tracker.checkout(obj, 1)
+	return obj, nil
+}
+
+func (p *Pool[T]) getWithPriority(ctx context.Context, priority int) (zero T, err error) {
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	if obj, found := p.tryGetFromL1(false); found {
+		return obj, nil
+	}
+
+	p.stats.l1GetMisses.Add(1)
+	p.notifyBackgroundFill()
+
+	if obj, found, _ := p.tryGetWithoutWaiting(); found {
+		return obj, nil
+	}
+
+	return p.waitForObject(ctx, priority)
+}
+
+// Waiters returns the number of callers waiting for an object.
+func (p *Pool[T]) Waiters() int {
+	return int(p.waiters.length.Load())
+}
+
+// getFromWaitQueue is the slow path of Get when the wait queue is enabled. It waits with the
+// lowest priority, bounded by the ring buffer read timeout if one is set, measured on the pool clock.
+func (p *Pool[T]) getFromWaitQueue(allocErr error) (zero T, err error) {
+	obj, err := p.waitForObjectWithTimeout(context.Background(), 0, p.config.ringBufferConfig.RTimeout)
+	if err != nil {
+		if allocErr != nil {
+			return zero, allocErr
+		}
+		return zero, err
+	}
+
+	return obj, nil
+}
+
+// waitForObject queues the caller until an object is handed to it by Put. The waiter at the head
+// of the queue also tries on its own when it's woken, since objects can become available without
+// a handOff, e.g. from the background fill.
+func (p *Pool[T]) waitForObject(ctx context.Context, priority int) (zero T, err error) {
+	return p.waitForObjectWithTimeout(ctx, priority, 0)
+}
+
+// waitForObjectWithTimeout is waitForObject giving up with context.DeadlineExceeded after timeout
+// on the pool clock, zero meaning no timeout.
+func (p *Pool[T]) waitForObjectWithTimeout(ctx context.Context, priority int, timeout time.Duration) (zero T, err error) {
+	w, err := p.waiters.enqueue(priority)
+	if err != nil {
+		return zero, err
+	}
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := p.config.clock.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C()
+	}
+
+	// An object may have been stored right before the waiter was queued, without a handOff.
+	p.waiters.wakeHead()
+
+	for {
+		select {
+		case obj := <-w.ch:
+			return obj, nil
+		case <-ctx.Done():
+			return p.abandonWait(w, ctx.Err())
+		case <-expired:
+			return p.abandonWait(w, context.DeadlineExceeded)
+		case <-p.ctx.Done():
+			return p.abandonWait(w, errPoolClosed)
+		case <-w.wake:
+			if !p.waiters.isHead(w) {
+				continue
+			}
+
+			obj, found, _ := p.tryGetWithoutWaiting()
+			if !found {
+				continue
+			}
+
+			if !p.waiters.remove(w) {
+				// An object was handed over at the same time, give it to the next waiter.
+				if err := p.handBack(<-w.ch); err != nil {
+					log.Printf("[WAIT] failed to hand back an object given to a served waiter: %v", err)
+				}
+			}
+
+			return obj, nil
+		}
+	}
+}
+
+// abandonWait removes w from the queue and returns err. If an object was already handed to w,
+// it's returned instead, since the caller would otherwise leak it.
+func (p *Pool[T]) abandonWait(w *waiter[T], err error) (zero T, _ error) {
+	if p.waiters.remove(w) {
+		return zero, err
+	}
+
+	return <-w.ch, nil
+}
+
+// handBack returns an object handed to a waiter that got another one meanwhile. The object was
+// never checked out by a caller, so unlike Put it skips the tracker and the cleaner.
+func (p *Pool[T]) handBack(obj T) error {
+	p.stats.objectsInUse.Add(-1)
+	return p.store(obj)
+}
+
+// tryGetWithoutWaiting attempts to get an object from L1, refilling it when no other
+// refill is in progress. Unlike tryRefillAndFromGetL1, it never waits on another caller's refill.
+func (p *Pool[T]) tryGetWithoutWaiting() (zero T, found bool, allocErr error) {
+	if obj, found := p.tryGetFromL1(false); found {
+		return obj, true, nil
+	}
+
+	select {
+	case p.refillSemaphore <- struct{}{}:
+		defer func() {
+			p.refillCond.Broadcast()
+			<-p.refillSemaphore
+			p.wakeWaiterIfStocked()
+		}()
+
+		obj, found, allocErr := p.handleRefillScenarios()
+		if found {
+			return obj, true, nil
+		}
+
+		// The refill fails once growth is blocked at the hard limit, even with objects left
+		// in the ring buffer.
+		if obj, found := p.tryGetFromRingBuffer(); found {
+			return obj, true, nil
+		}
+		return zero, false, allocErr
+	default:
+		return zero, false, nil
+	}
+}
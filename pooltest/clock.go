@@ -0,0 +1,197 @@
+package pooltest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+)
+
+// FakeClock is a pool.Clock whose time only moves when Advance is called, so shrink,
+// cooldown and backoff behavior can be tested without sleeping.
+//
+// Ticks are delivered synchronously: Advance hands each tick over on an unbuffered channel
+// and only moves on once it's received or the ticker is stopped. A pool's background goroutine
+// only receives a tick after finishing with the previous one, so the effects of a tick are
+// complete once the following tick has been received. Tickers must be read or stopped, the
+// pool stops the shrinker's ticker while it's parked after maxConsecutiveShrinks.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+
+	// tickersChanged is closed and replaced whenever a ticker is created.
+	tickersChanged chan struct{}
+}
+
+var _ pool.Clock = (*FakeClock)(nil)
+
+// NewFakeClock creates a fake clock set to start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{
+		now:            start,
+		tickersChanged: make(chan struct{}),
+	}
+}
+
+// Now returns the fake current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker returns a ticker driven by Advance.
+func (c *FakeClock) NewTicker(d time.Duration) pool.Ticker {
+	if d <= 0 {
+		panic("pooltest: non-positive interval for NewTicker")
+	}
+
+	return c.addTicker(d, false)
+}
+
+// NewTimer returns a ticker driven by Advance that ticks once, after d.
+// Non-positive durations tick on the next Advance.
+func (c *FakeClock) NewTimer(d time.Duration) pool.Ticker {
+	return c.addTicker(max(d, 0), true)
+}
+
+// addTicker registers a ticker whose first tick is due after d.
+func (c *FakeClock) addTicker(d time.Duration, once bool) *fakeTicker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTicker{
+		c:        make(chan time.Time),
+		stopped:  make(chan struct{}),
+		interval: d,
+		once:     once,
+		next:     c.now.Add(d),
+	}
+
+	c.tickers = append(c.tickers, t)
+
+	close(c.tickersChanged)
+	c.tickersChanged = make(chan struct{})
+
+	return t
+}
+
+// Advance moves the clock forward by d, delivering the ticks that fall due in order.
+// It blocks until each tick is received or its ticker is stopped, see FakeClock.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		t := c.nextDueTicker(target)
+		if t == nil {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+
+		tickTime := t.next
+		c.now = tickTime
+		t.next = tickTime.Add(t.interval)
+		c.mu.Unlock()
+
+		t.deliver(tickTime)
+		if t.once {
+			t.Stop()
+		}
+	}
+}
+
+// WaitForTickers blocks until at least n tickers are active, or the timeout elapses.
+// It's meant to be called after creating a pool, since its background goroutines
+// create their tickers asynchronously. Returns false on timeout.
+func (c *FakeClock) WaitForTickers(n int, timeout time.Duration) bool {
+	deadline := time.After(timeout)
+
+	for {
+		c.mu.Lock()
+		active := c.activeTickers()
+		changed := c.tickersChanged
+		c.mu.Unlock()
+
+		if active >= n {
+			return true
+		}
+
+		select {
+		case <-changed:
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+// nextDueTicker returns the active ticker with the earliest tick at or before target.
+// Must be called with c.mu held.
+func (c *FakeClock) nextDueTicker(target time.Time) *fakeTicker {
+	var due *fakeTicker
+	for _, t := range c.tickers {
+		if t.isStopped() || t.next.After(target) {
+			continue
+		}
+
+		if due == nil || t.next.Before(due.next) {
+			due = t
+		}
+	}
+
+	return due
+}
+
+// activeTickers counts the tickers that haven't been stopped. Must be called with c.mu held.
+func (c *FakeClock) activeTickers() int {
+	active := 0
+	for _, t := range c.tickers {
+		if !t.isStopped() {
+			active++
+		}
+	}
+	return active
+}
+
+// fakeTicker is a ticker whose ticks are delivered by FakeClock.Advance.
+type fakeTicker struct {
+	c        chan time.Time
+	stopped  chan struct{}
+	stopOnce sync.Once
+	interval time.Duration
+	next     time.Time
+
+	// once makes the ticker a timer, stopped after its first tick.
+	once bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.stopped)
+	})
+}
+
+// deliver hands tickTime over to the reader, or gives up once the ticker is stopped.
+func (t *fakeTicker) deliver(tickTime time.Time) {
+	select {
+	case t.c <- tickTime:
+	case <-t.stopped:
+	}
+}
+
+func (t *fakeTicker) isStopped() bool {
+	select {
+	case <-t.stopped:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,102 @@
+package pooltest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClockAdvance(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := pooltest.NewFakeClock(start)
+
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	ticks := make(chan time.Time, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 3 {
+			ticks <- <-ticker.C()
+		}
+	}()
+
+	clock.Advance(3500 * time.Millisecond)
+	<-done
+
+	assert.Equal(t, start.Add(3500*time.Millisecond), clock.Now())
+	require.Len(t, ticks, 3)
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, start.Add(time.Duration(i)*time.Second), <-ticks)
+	}
+}
+
+func TestFakeClockStoppedTicker(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Time{})
+
+	ticker := clock.NewTicker(time.Second)
+	assert.True(t, clock.WaitForTickers(1, time.Second))
+
+	ticker.Stop()
+	clock.Advance(time.Minute)
+
+	assert.False(t, clock.WaitForTickers(1, 10*time.Millisecond))
+}
+
+func TestFakeClockAdvanceWaitsForReader(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := pooltest.NewFakeClock(start)
+
+	ticker := clock.NewTicker(time.Second)
+
+	advanced := make(chan struct{})
+	go func() {
+		defer close(advanced)
+		clock.Advance(time.Second)
+	}()
+
+	select {
+	case <-advanced:
+		t.Fatal("Advance returned before the tick was received")
+	case tick := <-ticker.C():
+		assert.Equal(t, start.Add(time.Second), tick)
+	}
+	<-advanced
+
+	go func() {
+		clock.Advance(time.Second)
+	}()
+
+	ticker.Stop()
+	assert.Eventually(t, func() bool {
+		return clock.Now().Equal(start.Add(2 * time.Second))
+	}, time.Second, time.Millisecond, "stopping the ticker releases a pending Advance")
+}
+
+func TestFakeClockTimer(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := pooltest.NewFakeClock(start)
+
+	timer := clock.NewTimer(time.Second)
+	defer timer.Stop()
+
+	ticks := make(chan time.Time, 1)
+	go func() {
+		ticks <- <-timer.C()
+	}()
+
+	clock.Advance(999 * time.Millisecond)
+	assert.Empty(t, ticks)
+
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, start.Add(time.Second), <-ticks)
+
+	clock.Advance(5 * time.Second)
+	assert.Empty(t, ticks, "a timer ticks once")
+	assert.False(t, clock.WaitForTickers(1, 10*time.Millisecond))
+}
@@ -0,0 +1,3 @@
+// Package pooltest provides helpers for testing code built on PoolX pools: a fake clock,
+// an invariant checker and allocators and cleaners that fail on demand.
+package pooltest
@@ -0,0 +1,90 @@
+package pooltest
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrInjected is the error returned by allocators when FaultError is injected.
+var ErrInjected = errors.New("pooltest: injected fault")
+
+// Fault is a failure mode that can be injected into allocators and cleaners.
+type Fault int32
+
+const (
+	// FaultNone lets calls through untouched.
+	FaultNone Fault = iota
+
+	// FaultPanic makes calls panic.
+	FaultPanic
+
+	// FaultError makes allocators return ErrInjected. Cleaners can't fail, so they're unaffected.
+	FaultError
+
+	// FaultStall makes calls sleep for the configured stall duration before proceeding.
+	FaultStall
+)
+
+// Faults controls the failures injected into the functions wrapped with FaultyAllocator
+// and FaultyCleaner. It can be changed at any time, including while the pool is in use.
+// The zero value injects no faults.
+type Faults struct {
+	fault    atomic.Int32
+	stall    atomic.Int64
+	injected atomic.Int64
+}
+
+// Set changes the fault injected into subsequent calls.
+func (f *Faults) Set(fault Fault) {
+	f.fault.Store(int32(fault))
+}
+
+// SetStall sets how long calls sleep when FaultStall is injected.
+func (f *Faults) SetStall(d time.Duration) {
+	f.stall.Store(int64(d))
+}
+
+// Injected returns the number of calls a fault was injected into.
+func (f *Faults) Injected() int64 {
+	return f.injected.Load()
+}
+
+// inject applies the current fault, returning ErrInjected for FaultError.
+func (f *Faults) inject() error {
+	switch Fault(f.fault.Load()) {
+	case FaultPanic:
+		f.injected.Add(1)
+		panic(ErrInjected)
+	case FaultError:
+		f.injected.Add(1)
+		return ErrInjected
+	case FaultStall:
+		f.injected.Add(1)
+		time.Sleep(time.Duration(f.stall.Load()))
+	}
+
+	return nil
+}
+
+// FaultyAllocator wraps allocator into an allocator for pool.NewPoolWithFallibleAllocator
+// that panics, fails or stalls according to faults.
+func FaultyAllocator[T any](faults *Faults, allocator func() T) func(context.Context) (T, error) {
+	return func(ctx context.Context) (zero T, err error) {
+		if err := faults.inject(); err != nil {
+			return zero, err
+		}
+		return allocator(), nil
+	}
+}
+
+// FaultyCleaner wraps cleaner into a cleaner that panics or stalls according to faults.
+func FaultyCleaner[T any](faults *Faults, cleaner func(T)) func(T) {
+	return func(obj T) {
+		if Fault(faults.fault.Load()) != FaultError {
+			_ = faults.inject()
+		}
+		cleaner(obj)
+	}
+}
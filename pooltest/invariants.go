@@ -0,0 +1,49 @@
+package pooltest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+)
+
+// CheckInvariants verifies the accounting invariants of a pool and returns every violation found:
+//   - live objects (created - destroyed) are between zero and the hard limit
+//   - the objects stored in L1 and the ring buffer don't exceed the live objects
+//   - objects in use are non-negative, i.e. there are no more returns than gets
+//   - objects in use don't exceed the live objects
+//
+// Snapshots are point-in-time consistent, so the checks hold under concurrent operations too.
+func CheckInvariants[T any](p *pool.Pool[T]) error {
+	return CheckSnapshotInvariants(p.GetPoolStatsSnapshot())
+}
+
+// CheckSnapshotInvariants verifies the invariants of CheckInvariants on an existing snapshot.
+func CheckSnapshotInvariants(s *pool.PoolStatsSnapshot) error {
+	var errs []error
+
+	live := s.ObjectsCreated - s.ObjectsDestroyed
+	if live < 0 {
+		errs = append(errs, fmt.Errorf("live objects (%d) is negative: created %d, destroyed %d", live, s.ObjectsCreated, s.ObjectsDestroyed))
+	}
+
+	if live > s.HardLimit {
+		errs = append(errs, fmt.Errorf("live objects (%d) exceeds hard limit (%d)", live, s.HardLimit))
+	}
+
+	stored := s.L1Length + s.RingBufferLength
+	if stored > live {
+		errs = append(errs, fmt.Errorf("stored objects (%d in L1 + %d in ring buffer) exceeds live objects (%d)", s.L1Length, s.RingBufferLength, live))
+	}
+
+	if live >= 0 && s.ObjectsInUse > uint64(live) {
+		errs = append(errs, fmt.Errorf("objects in use (%d) exceeds live objects (%d)", s.ObjectsInUse, live))
+	}
+
+	returns := s.FastReturnHit + s.FastReturnMiss
+	if returns > s.TotalGets {
+		errs = append(errs, fmt.Errorf("objects in use is negative: %d returns for %d gets", returns, s.TotalGets))
+	}
+
+	return errors.Join(errs...)
+}
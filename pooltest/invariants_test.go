@@ -0,0 +1,138 @@
+package pooltest_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlexsanderHamir/PoolX/v2/pool"
+	"github.com/AlexsanderHamir/PoolX/v2/pooltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type object struct {
+	value int
+}
+
+func TestCheckSnapshotInvariants(t *testing.T) {
+	valid := &pool.PoolStatsSnapshot{
+		HardLimit:        10,
+		ObjectsCreated:   8,
+		ObjectsDestroyed: 2,
+		L1Length:         2,
+		RingBufferLength: 3,
+		TotalGets:        5,
+		FastReturnHit:    3,
+		FastReturnMiss:   1,
+	}
+	assert.NoError(t, pooltest.CheckSnapshotInvariants(valid))
+
+	aboveHardLimit := *valid
+	aboveHardLimit.ObjectsCreated = 13
+	assert.ErrorContains(t, pooltest.CheckSnapshotInvariants(&aboveHardLimit), "exceeds hard limit")
+
+	overStored := *valid
+	overStored.RingBufferLength = 5
+	assert.ErrorContains(t, pooltest.CheckSnapshotInvariants(&overStored), "exceeds live objects")
+
+	overInUse := *valid
+	overInUse.ObjectsInUse = 7
+	assert.ErrorContains(t, pooltest.CheckSnapshotInvariants(&overInUse), "objects in use (7) exceeds live objects")
+
+	negativeInUse := *valid
+	negativeInUse.FastReturnMiss = 3
+	assert.ErrorContains(t, pooltest.CheckSnapshotInvariants(&negativeInUse), "objects in use is negative")
+}
+
+func TestCheckInvariantsWithFaults(t *testing.T) {
+	config, err := pool.NewPoolConfigBuilder[*object]().
+		SetInitialCapacity(8).
+		SetHardLimit(32).
+		SetMinShrinkCapacity(8).
+		SetRingBufferBlocking(false).
+		SetRetryPolicy(pool.RetryPolicy{Mode: pool.RetryNone}).
+		Build()
+	require.NoError(t, err)
+
+	var faults pooltest.Faults
+	allocator := pooltest.FaultyAllocator(&faults, func() *object {
+		return &object{}
+	})
+	cleaner := func(obj *object) {
+		obj.value = 0
+	}
+
+	p, err := pool.NewPoolWithFallibleAllocator(config, allocator, cleaner, nil)
+	require.NoError(t, err)
+	poolObj := p.(*pool.Pool[*object])
+	defer func() {
+		require.NoError(t, p.Close())
+	}()
+
+	faults.Set(pooltest.FaultError)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				held := make([]*object, 0, 4)
+				for range 4 {
+					if obj, err := p.Get(); err == nil {
+						held = append(held, obj)
+					}
+				}
+				for _, obj := range held {
+					assert.NoError(t, p.Put(obj))
+				}
+			}
+		}()
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	faults.Set(pooltest.FaultNone)
+	wg.Wait()
+
+	assert.Greater(t, faults.Injected(), int64(0))
+	assert.NoError(t, pooltest.CheckInvariants(poolObj))
+}
+
+func TestFaults(t *testing.T) {
+	var faults pooltest.Faults
+	allocator := pooltest.FaultyAllocator(&faults, func() *object {
+		return &object{value: 1}
+	})
+
+	obj, err := allocator(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, obj.value)
+
+	faults.Set(pooltest.FaultError)
+	_, err = allocator(context.Background())
+	assert.True(t, errors.Is(err, pooltest.ErrInjected))
+
+	faults.Set(pooltest.FaultPanic)
+	assert.Panics(t, func() {
+		_, _ = allocator(context.Background())
+	})
+
+	cleaner := pooltest.FaultyCleaner(&faults, func(obj *object) {
+		obj.value = 0
+	})
+	assert.Panics(t, func() {
+		cleaner(&object{})
+	})
+
+	faults.Set(pooltest.FaultStall)
+	faults.SetStall(20 * time.Millisecond)
+	start := time.Now()
+	cleaner(&object{})
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	assert.Equal(t, int64(4), faults.Injected())
+}